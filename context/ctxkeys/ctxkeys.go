@@ -0,0 +1,42 @@
+// Package ctxkeys provides typed keys for context values.
+//
+// context.WithValue with string keys invites collisions between packages and
+// needs a type assertion on every read. A Key is compared by identity and
+// remembers the type of its value, so neither can go wrong.
+package ctxkeys
+
+import "context"
+
+// Key identifies a context value of type T. Create one with NewKey and keep
+// it in a package-level variable; two keys never match, even with the same name.
+type Key[T any] struct {
+	k *key
+}
+
+// key is the unexported type actually stored in the context, so no other
+// package can build a colliding key.
+type key struct {
+	name string
+}
+
+// NewKey returns a new Key. name is only used for debugging.
+func NewKey[T any](name string) Key[T] {
+	return Key[T]{k: &key{name: name}}
+}
+
+// String returns the name the key was created with.
+func (k Key[T]) String() string {
+	return k.k.name
+}
+
+// Set returns a copy of ctx carrying value under key.
+func Set[T any](ctx context.Context, key Key[T], value T) context.Context {
+	return context.WithValue(ctx, key.k, value)
+}
+
+// Get returns the value stored under key, or the zero value and false if
+// ctx doesn't carry one.
+func Get[T any](ctx context.Context, key Key[T]) (T, bool) {
+	value, ok := ctx.Value(key.k).(T)
+	return value, ok
+}
@@ -0,0 +1,47 @@
+package ctxkeys
+
+import (
+	"context"
+	"testing"
+)
+
+var (
+	requestID = NewKey[string]("request-id")
+	tenantID  = NewKey[int]("tenant-id")
+)
+
+func TestGet(t *testing.T) {
+	t.Run("round-trips a value", func(t *testing.T) {
+		ctx := Set(context.Background(), requestID, "req-123")
+		ctx = Set(ctx, tenantID, 42)
+
+		gotRequest, ok := Get(ctx, requestID)
+		if !ok || gotRequest != "req-123" {
+			t.Errorf("got %q, %v, want %q, true", gotRequest, ok, "req-123")
+		}
+
+		gotTenant, ok := Get(ctx, tenantID)
+		if !ok || gotTenant != 42 {
+			t.Errorf("got %d, %v, want 42, true", gotTenant, ok)
+		}
+	})
+
+	t.Run("missing key returns the zero value and false", func(t *testing.T) {
+		got, ok := Get(context.Background(), requestID)
+		if ok || got != "" {
+			t.Errorf("got %q, %v, want empty and false", got, ok)
+		}
+	})
+
+	t.Run("keys with the same name don't collide", func(t *testing.T) {
+		other := NewKey[string]("request-id")
+		ctx := Set(context.Background(), requestID, "req-123")
+
+		if got, ok := Get(ctx, other); ok {
+			t.Errorf("got %q from another key with the same name", got)
+		}
+		if requestID.String() != "request-id" {
+			t.Errorf("got name %q, want %q", requestID.String(), "request-id")
+		}
+	})
+}
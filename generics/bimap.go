@@ -0,0 +1,48 @@
+package generics
+
+// BiMap is a one-to-one map that can be looked up by key or by value.
+type BiMap[K, V comparable] struct {
+	forward map[K]V
+	reverse map[V]K
+}
+
+func NewBiMap[K, V comparable]() *BiMap[K, V] {
+	return &BiMap[K, V]{
+		forward: make(map[K]V),
+		reverse: make(map[V]K),
+	}
+}
+
+// Put associates key with value. Any existing pairing of either the key or
+// the value is removed first, so the map stays one-to-one.
+func (b *BiMap[K, V]) Put(key K, value V) {
+	if oldValue, ok := b.forward[key]; ok {
+		delete(b.reverse, oldValue)
+	}
+	if oldKey, ok := b.reverse[value]; ok {
+		delete(b.forward, oldKey)
+	}
+	b.forward[key] = value
+	b.reverse[value] = key
+}
+
+func (b *BiMap[K, V]) GetByKey(key K) (V, bool) {
+	value, ok := b.forward[key]
+	return value, ok
+}
+
+func (b *BiMap[K, V]) GetByValue(value V) (K, bool) {
+	key, ok := b.reverse[value]
+	return key, ok
+}
+
+func (b *BiMap[K, V]) Delete(key K) {
+	if value, ok := b.forward[key]; ok {
+		delete(b.reverse, value)
+		delete(b.forward, key)
+	}
+}
+
+func (b *BiMap[K, V]) Len() int {
+	return len(b.forward)
+}
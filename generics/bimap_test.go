@@ -0,0 +1,55 @@
+package generics
+
+import "testing"
+
+func TestBiMap(t *testing.T) {
+	t.Run("looks up in both directions", func(t *testing.T) {
+		users := NewBiMap[int, string]()
+		users.Put(1, "ann")
+		users.Put(2, "bob")
+
+		name, ok := users.GetByKey(1)
+		AssertTrue(t, ok)
+		AssertEqual(t, name, "ann")
+
+		id, ok := users.GetByValue("bob")
+		AssertTrue(t, ok)
+		AssertEqual(t, id, 2)
+	})
+
+	t.Run("overwriting a key updates the reverse index", func(t *testing.T) {
+		users := NewBiMap[int, string]()
+		users.Put(1, "ann")
+		users.Put(1, "annie")
+
+		_, ok := users.GetByValue("ann")
+		AssertFalse(t, ok)
+		id, _ := users.GetByValue("annie")
+		AssertEqual(t, id, 1)
+		AssertEqual(t, users.Len(), 1)
+	})
+
+	t.Run("reusing a value moves it to the new key", func(t *testing.T) {
+		users := NewBiMap[int, string]()
+		users.Put(1, "ann")
+		users.Put(2, "ann")
+
+		_, ok := users.GetByKey(1)
+		AssertFalse(t, ok)
+		id, _ := users.GetByValue("ann")
+		AssertEqual(t, id, 2)
+		AssertEqual(t, users.Len(), 1)
+	})
+
+	t.Run("Delete removes both directions", func(t *testing.T) {
+		users := NewBiMap[int, string]()
+		users.Put(1, "ann")
+		users.Delete(1)
+		users.Delete(42)
+
+		_, ok := users.GetByKey(1)
+		AssertFalse(t, ok)
+		_, ok = users.GetByValue("ann")
+		AssertFalse(t, ok)
+	})
+}
@@ -0,0 +1,63 @@
+package generics
+
+import (
+	"cmp"
+	"iter"
+)
+
+// BST is an unbalanced binary search tree.
+// Inserting a value that is already in the tree does nothing, so the tree
+// behaves like a sorted set.
+type BST[T cmp.Ordered] struct {
+	root *node[T]
+}
+
+type node[T cmp.Ordered] struct {
+	value       T
+	left, right *node[T]
+}
+
+func (t *BST[T]) Insert(value T) {
+	link := &t.root
+	for *link != nil {
+		switch n := *link; {
+		case value < n.value:
+			link = &n.left
+		case value > n.value:
+			link = &n.right
+		default:
+			return // duplicate, ignore it
+		}
+	}
+	*link = &node[T]{value: value}
+}
+
+func (t *BST[T]) Contains(value T) bool {
+	n := t.root
+	for n != nil {
+		switch {
+		case value < n.value:
+			n = n.left
+		case value > n.value:
+			n = n.right
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// InOrder yields the values in ascending order.
+func (t *BST[T]) InOrder() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t.root.walk(yield)
+	}
+}
+
+// walk visits n's subtree in order, returning false once yield asks to stop.
+func (n *node[T]) walk(yield func(T) bool) bool {
+	if n == nil {
+		return true
+	}
+	return n.left.walk(yield) && yield(n.value) && n.right.walk(yield)
+}
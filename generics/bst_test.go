@@ -0,0 +1,53 @@
+package generics
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBST(t *testing.T) {
+	t.Run("in-order traversal is sorted", func(t *testing.T) {
+		var tree BST[int]
+		for _, n := range []int{50, 30, 70, 20, 40, 60, 80, 30} {
+			tree.Insert(n)
+		}
+
+		got := slices.Collect(tree.InOrder())
+
+		AssertTrue(t, slices.Equal(got, []int{20, 30, 40, 50, 60, 70, 80}))
+	})
+
+	t.Run("Contains", func(t *testing.T) {
+		var tree BST[string]
+		tree.Insert("grace")
+		tree.Insert("ada")
+		tree.Insert("linus")
+
+		AssertTrue(t, tree.Contains("ada"))
+		AssertTrue(t, tree.Contains("linus"))
+		AssertFalse(t, tree.Contains("ken"))
+	})
+
+	t.Run("stopping early", func(t *testing.T) {
+		var tree BST[int]
+		for _, n := range []int{3, 1, 2} {
+			tree.Insert(n)
+		}
+
+		var got []int
+		for v := range tree.InOrder() {
+			got = append(got, v)
+			if len(got) == 2 {
+				break
+			}
+		}
+
+		AssertTrue(t, slices.Equal(got, []int{1, 2}))
+	})
+
+	t.Run("empty tree", func(t *testing.T) {
+		var tree BST[int]
+		AssertFalse(t, tree.Contains(1))
+		AssertEqual(t, len(slices.Collect(tree.InOrder())), 0)
+	})
+}
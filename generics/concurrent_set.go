@@ -0,0 +1,54 @@
+package generics
+
+import "sync"
+
+// ConcurrentSet is a set that is safe to use from several goroutines.
+// The zero value is an empty set ready to use.
+type ConcurrentSet[T comparable] struct {
+	mu    sync.RWMutex
+	items map[T]struct{}
+}
+
+func NewConcurrentSet[T comparable]() *ConcurrentSet[T] {
+	return &ConcurrentSet[T]{items: make(map[T]struct{})}
+}
+
+func (s *ConcurrentSet[T]) Add(value T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.items == nil {
+		s.items = make(map[T]struct{})
+	}
+	s.items[value] = struct{}{}
+}
+
+func (s *ConcurrentSet[T]) Remove(value T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.items, value)
+}
+
+func (s *ConcurrentSet[T]) Contains(value T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.items[value]
+	return ok
+}
+
+func (s *ConcurrentSet[T]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.items)
+}
+
+// Snapshot returns the values in the set at one moment, in no particular
+// order. Later changes to the set don't affect the returned slice.
+func (s *ConcurrentSet[T]) Snapshot() []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	values := make([]T, 0, len(s.items))
+	for v := range s.items {
+		values = append(values, v)
+	}
+	return values
+}
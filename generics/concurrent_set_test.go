@@ -0,0 +1,53 @@
+package generics
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentSet(t *testing.T) {
+	t.Run("Add, Remove and Contains", func(t *testing.T) {
+		var set ConcurrentSet[string]
+		set.Add("ann")
+		set.Add("bob")
+		set.Add("ann")
+		set.Remove("bob")
+
+		AssertTrue(t, set.Contains("ann"))
+		AssertFalse(t, set.Contains("bob"))
+		AssertEqual(t, set.Len(), 1)
+	})
+
+	t.Run("concurrent use keeps snapshots consistent", func(t *testing.T) {
+		set := NewConcurrentSet[int]()
+		// even numbers stay, odd numbers are added and then removed again
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				set.Add(i)
+				if i%2 == 1 {
+					set.Remove(i)
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				snapshot := set.Snapshot()
+				AssertTrue(t, len(snapshot) <= 100)
+			}()
+		}
+		wg.Wait()
+
+		snapshot := set.Snapshot()
+		slices.Sort(snapshot)
+		AssertEqual(t, len(snapshot), 50)
+		for i, v := range snapshot {
+			AssertEqual(t, v, i*2)
+		}
+
+		set.Add(1000)
+		AssertEqual(t, len(snapshot), 50) // the snapshot is a copy
+	})
+}
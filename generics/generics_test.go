@@ -1,6 +1,11 @@
 package generics
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
 
 func TestAssertFunctions(t *testing.T) {
 	t.Run("asserting on integers", func(t *testing.T) {
@@ -43,3 +48,90 @@ func TestStack(t *testing.T) {
 		AssertEqual(t, firstNum+secondNum, 3)
 	})
 }
+
+func TestStackErrors(t *testing.T) {
+	t.Run("popping an empty stack", func(t *testing.T) {
+		_, err := NewStack[int]().TryPop()
+
+		AssertTrue(t, errors.Is(err, ErrEmptyStack))
+
+		var stackErr StackError
+		AssertTrue(t, errors.As(err, &stackErr))
+		AssertEqual(t, stackErr.Op, "pop")
+	})
+
+	t.Run("pushing onto a full bounded stack", func(t *testing.T) {
+		stack := NewBoundedStack[string](1)
+		AssertEqual(t, stack.Push("first"), nil)
+
+		err := stack.Push("second")
+
+		AssertTrue(t, errors.Is(err, ErrStackFull))
+
+		var stackErr StackError
+		AssertTrue(t, errors.As(err, &stackErr))
+		AssertEqual(t, stackErr.Op, "push")
+		AssertEqual(t, err.Error(), "stack push: stack is full")
+	})
+
+	t.Run("TryPop returns values while there are some", func(t *testing.T) {
+		stack := NewBoundedStack[int](2)
+		stack.Push(1)
+
+		value, err := stack.TryPop()
+
+		AssertEqual(t, err, nil)
+		AssertEqual(t, value, 1)
+	})
+}
+
+func TestStackIterators(t *testing.T) {
+	t.Run("All yields from top to bottom", func(t *testing.T) {
+		stack := NewStack[int]()
+		stack.Push(1)
+		stack.Push(2)
+		stack.Push(3)
+
+		got := slices.Collect(stack.All())
+
+		AssertTrue(t, slices.Equal(got, []int{3, 2, 1}))
+		AssertFalse(t, stack.IsEmpty())
+	})
+
+	t.Run("AllContext stops when the context is cancelled", func(t *testing.T) {
+		stack := NewStack[int]()
+		for i := 0; i < 100; i++ {
+			stack.Push(i)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		seen := 0
+		for range stack.AllContext(ctx) {
+			seen++
+			if seen == 3 {
+				cancel()
+			}
+		}
+
+		AssertEqual(t, seen, 3)
+	})
+}
+
+func TestStackShrinkToFit(t *testing.T) {
+	stack := NewStack[int]()
+	for i := 0; i < 1000; i++ {
+		stack.Push(i)
+	}
+	for i := 0; i < 990; i++ {
+		stack.Pop()
+	}
+	before := cap(stack.values)
+
+	stack.ShrinkToFit()
+
+	AssertEqual(t, stack.Len(), 10)
+	AssertEqual(t, cap(stack.values), 10)
+	AssertTrue(t, cap(stack.values) < before)
+	AssertTrue(t, slices.Equal(slices.Collect(stack.All()), []int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}))
+}
@@ -0,0 +1,67 @@
+package generics
+
+import (
+	"container/list"
+	"iter"
+)
+
+// OrderedMap is a map that iterates in the order keys were first set.
+// Setting a key that is already present updates its value but keeps its
+// original position; delete and set it again to move it to the end.
+// It is not safe for concurrent use.
+type OrderedMap[K comparable, V any] struct {
+	order   *list.List // of *orderedEntry, oldest first
+	entries map[K]*list.Element
+}
+
+type orderedEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{
+		order:   list.New(),
+		entries: make(map[K]*list.Element),
+	}
+}
+
+func (m *OrderedMap[K, V]) Set(key K, value V) {
+	if el, ok := m.entries[key]; ok {
+		el.Value.(*orderedEntry[K, V]).value = value
+		return
+	}
+	m.entries[key] = m.order.PushBack(&orderedEntry[K, V]{key: key, value: value})
+}
+
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+	el, ok := m.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return el.Value.(*orderedEntry[K, V]).value, true
+}
+
+func (m *OrderedMap[K, V]) Delete(key K) {
+	if el, ok := m.entries[key]; ok {
+		m.order.Remove(el)
+		delete(m.entries, key)
+	}
+}
+
+func (m *OrderedMap[K, V]) Len() int {
+	return len(m.entries)
+}
+
+// All yields the keys and values in insertion order.
+func (m *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for el := m.order.Front(); el != nil; el = el.Next() {
+			entry := el.Value.(*orderedEntry[K, V])
+			if !yield(entry.key, entry.value) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package generics
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestOrderedMap(t *testing.T) {
+	keys := func(m *OrderedMap[string, int]) []string {
+		var got []string
+		for k := range m.All() {
+			got = append(got, k)
+		}
+		return got
+	}
+
+	t.Run("iterates in insertion order", func(t *testing.T) {
+		attributes := NewOrderedMap[string, int]()
+		attributes.Set("name", 1)
+		attributes.Set("email", 2)
+		attributes.Set("age", 3)
+
+		AssertTrue(t, slices.Equal(keys(attributes), []string{"name", "email", "age"}))
+		AssertEqual(t, attributes.Len(), 3)
+	})
+
+	t.Run("re-setting a key keeps its position", func(t *testing.T) {
+		attributes := NewOrderedMap[string, int]()
+		attributes.Set("name", 1)
+		attributes.Set("email", 2)
+		attributes.Set("name", 10)
+
+		AssertTrue(t, slices.Equal(keys(attributes), []string{"name", "email"}))
+		value, ok := attributes.Get("name")
+		AssertTrue(t, ok)
+		AssertEqual(t, value, 10)
+	})
+
+	t.Run("deleting removes the key from iteration", func(t *testing.T) {
+		attributes := NewOrderedMap[string, int]()
+		attributes.Set("name", 1)
+		attributes.Set("email", 2)
+		attributes.Set("age", 3)
+		attributes.Delete("email")
+		attributes.Set("email", 4)
+
+		AssertTrue(t, slices.Equal(keys(attributes), []string{"name", "age", "email"}))
+		_, ok := attributes.Get("missing")
+		AssertFalse(t, ok)
+	})
+
+	t.Run("stops when the caller breaks", func(t *testing.T) {
+		attributes := NewOrderedMap[string, int]()
+		attributes.Set("name", 1)
+		attributes.Set("email", 2)
+
+		seen := 0
+		for range attributes.All() {
+			seen++
+			break
+		}
+
+		AssertEqual(t, seen, 1)
+	})
+}
@@ -0,0 +1,53 @@
+package generics
+
+import "container/heap"
+
+// PriorityQueue pops values in priority order, as decided by less.
+// With less(a, b) returning a < b it is a min-heap.
+type PriorityQueue[T any] struct {
+	h *heapOf[T]
+}
+
+func NewPriorityQueue[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{h: &heapOf[T]{less: less}}
+}
+
+func (pq *PriorityQueue[T]) Push(value T) {
+	heap.Push(pq.h, value)
+}
+
+func (pq *PriorityQueue[T]) Pop() (T, bool) {
+	if pq.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+	return heap.Pop(pq.h).(T), true
+}
+
+func (pq *PriorityQueue[T]) Len() int {
+	return pq.h.Len()
+}
+
+// heapOf implements heap.Interface, which still speaks in terms of any,
+// so PriorityQueue can offer a typed API on top of it.
+type heapOf[T any] struct {
+	values []T
+	less   func(a, b T) bool
+}
+
+func (h *heapOf[T]) Len() int           { return len(h.values) }
+func (h *heapOf[T]) Less(i, j int) bool { return h.less(h.values[i], h.values[j]) }
+func (h *heapOf[T]) Swap(i, j int)      { h.values[i], h.values[j] = h.values[j], h.values[i] }
+
+func (h *heapOf[T]) Push(x any) {
+	h.values = append(h.values, x.(T))
+}
+
+func (h *heapOf[T]) Pop() any {
+	index := len(h.values) - 1
+	el := h.values[index]
+	var zero T
+	h.values[index] = zero // don't hold on to popped values
+	h.values = h.values[:index]
+	return el
+}
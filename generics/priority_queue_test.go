@@ -0,0 +1,56 @@
+package generics
+
+import "testing"
+
+func TestPriorityQueue(t *testing.T) {
+	t.Run("min-heap of ints", func(t *testing.T) {
+		pq := NewPriorityQueue(func(a, b int) bool { return a < b })
+		for _, n := range []int{5, 1, 4, 2, 3} {
+			pq.Push(n)
+		}
+
+		AssertEqual(t, pq.Len(), 5)
+		for want := 1; want <= 5; want++ {
+			got, ok := pq.Pop()
+			AssertTrue(t, ok)
+			AssertEqual(t, got, want)
+		}
+
+		_, ok := pq.Pop()
+		AssertFalse(t, ok)
+	})
+
+	t.Run("custom struct priority", func(t *testing.T) {
+		type job struct {
+			name     string
+			priority int
+		}
+		pq := NewPriorityQueue(func(a, b job) bool { return a.priority > b.priority })
+
+		pq.Push(job{"backup", 1})
+		pq.Push(job{"page on-call", 10})
+		pq.Push(job{"send email", 5})
+
+		got, _ := pq.Pop()
+		AssertEqual(t, got.name, "page on-call")
+	})
+
+	t.Run("interleaved push and pop", func(t *testing.T) {
+		pq := NewPriorityQueue(func(a, b int) bool { return a < b })
+
+		pq.Push(3)
+		pq.Push(1)
+		got, _ := pq.Pop()
+		AssertEqual(t, got, 1)
+
+		pq.Push(0)
+		pq.Push(2)
+		got, _ = pq.Pop()
+		AssertEqual(t, got, 0)
+		got, _ = pq.Pop()
+		AssertEqual(t, got, 2)
+		got, _ = pq.Pop()
+		AssertEqual(t, got, 3)
+		AssertEqual(t, pq.Len(), 0)
+	})
+}
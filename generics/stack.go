@@ -1,5 +1,30 @@
 package generics
 
+import (
+	"context"
+	"errors"
+	"iter"
+)
+
+var (
+	ErrEmptyStack = errors.New("stack is empty")
+	ErrStackFull  = errors.New("stack is full")
+)
+
+// StackError records which stack operation failed and why.
+type StackError struct {
+	Op  string
+	Err error
+}
+
+func (e StackError) Error() string {
+	return "stack " + e.Op + ": " + e.Err.Error()
+}
+
+func (e StackError) Unwrap() error {
+	return e.Err
+}
+
 type Stack[T any] struct {
 	values []T
 }
@@ -16,6 +41,19 @@ func (s *Stack[T]) IsEmpty() bool {
 	return len(s.values) == 0
 }
 
+func (s *Stack[T]) Len() int {
+	return len(s.values)
+}
+
+// ShrinkToFit copies the values into a backing array of exactly Len()
+// capacity, so the memory held after a burst of pushes can be reclaimed.
+func (s *Stack[T]) ShrinkToFit() {
+	if cap(s.values) == len(s.values) {
+		return
+	}
+	s.values = append(make([]T, 0, len(s.values)), s.values...)
+}
+
 func (s *Stack[T]) Pop() (T, bool) {
 	if s.IsEmpty() {
 		var zero T
@@ -27,3 +65,53 @@ func (s *Stack[T]) Pop() (T, bool) {
 	s.values = s.values[:index]
 	return el, true
 }
+
+// All yields the values from the top of the stack to the bottom, the order
+// Pop would return them in, without removing them.
+func (s *Stack[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := len(s.values) - 1; i >= 0; i-- {
+			if !yield(s.values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// AllContext is All, but stops yielding once ctx is done.
+func (s *Stack[T]) AllContext(ctx context.Context) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range s.All() {
+			if ctx.Err() != nil || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// TryPop is Pop for callers who want an error rather than a bool.
+func (s *Stack[T]) TryPop() (T, error) {
+	value, ok := s.Pop()
+	if !ok {
+		return value, StackError{Op: "pop", Err: ErrEmptyStack}
+	}
+	return value, nil
+}
+
+// BoundedStack is a Stack that holds at most capacity values.
+type BoundedStack[T any] struct {
+	Stack[T]
+	capacity int
+}
+
+func NewBoundedStack[T any](capacity int) *BoundedStack[T] {
+	return &BoundedStack[T]{capacity: capacity}
+}
+
+func (s *BoundedStack[T]) Push(value T) error {
+	if len(s.values) >= s.capacity {
+		return StackError{Op: "push", Err: ErrStackFull}
+	}
+	s.Stack.Push(value)
+	return nil
+}
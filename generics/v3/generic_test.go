@@ -1,6 +1,12 @@
 package v3
 
-import "testing"
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
 
 func TestAssertFunctions(t *testing.T) {
 	t.Run("asserting on integers", func(t *testing.T) {
@@ -17,6 +23,21 @@ func TestAssertFunctions(t *testing.T) {
 	// AssertEqual(t, 1, "1") // uncomment to see the error
 }
 
+func TestAssertEqualCmp(t *testing.T) {
+	type Account struct {
+		Name      string
+		Tags      []string
+		CreatedAt time.Time
+	}
+
+	got := Account{Name: "Ann", Tags: []string{"admin"}, CreatedAt: time.Now()}
+	want := Account{Name: "Ann", Tags: []string{"admin"}}
+
+	// Account 含有 slice，不是 comparable，無法使用 AssertEqual
+	AssertEqualCmp(t, got, want, cmpopts.IgnoreFields(Account{}, "CreatedAt"))
+	// AssertEqualCmp(t, got, want) // uncomment to see the diff
+}
+
 // [T comparable]︰類型參數的類型是 comparable，我們給它的標籤是 T
 // 我們使用 comparable 因為我們要向 Compiler 描述，
 // 我們希望在函式中對 T 類型的東西使用 == 和 != 運算符號，我們想要比較！
@@ -37,3 +58,14 @@ func AssertNotEqual[T comparable](t *testing.T, got, want T) {
 		t.Errorf("didn't want %v", got)
 	}
 }
+
+// AssertEqualCmp 以 go-cmp 比較 got 與 want，T 可以是任何型別
+// 透過 opts 可以忽略欄位（cmpopts.IgnoreFields）或自訂比較方式（cmp.Comparer）
+// 失敗時輸出 -want +got 的差異
+func AssertEqualCmp[T any](t *testing.T, got, want T, opts ...cmp.Option) {
+	t.Helper()
+
+	if diff := cmp.Diff(want, got, opts...); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
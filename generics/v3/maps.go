@@ -0,0 +1,50 @@
+package v3
+
+// Entry 是 map 中的一組 key/value
+type Entry[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// Keys 回傳 m 所有的 key
+// map 的走訪順序是不固定的，所以回傳的順序也不固定
+func Keys[K comparable, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Values 回傳 m 所有的 value，順序不固定
+func Values[K comparable, V any](m map[K]V) []V {
+	values := make([]V, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return values
+}
+
+// Entries 回傳 m 所有的 key/value，順序不固定
+func Entries[K comparable, V any](m map[K]V) []Entry[K, V] {
+	entries := make([]Entry[K, V], 0, len(m))
+	for k, v := range m {
+		entries = append(entries, Entry[K, V]{Key: k, Value: v})
+	}
+	return entries
+}
+
+// Index 以 keyFn 算出的 key 建立 items 的查詢表
+// key 重複時保留最後一個 item；需要保留全部時請用 IndexMulti
+func Index[T any, K comparable](items []T, keyFn func(T) K) map[K]T {
+	index := make(map[K]T, len(items))
+	for _, item := range items {
+		index[keyFn(item)] = item
+	}
+	return index
+}
+
+// IndexMulti 與 Index 相同，但同一個 key 的所有 item 都會依原本的順序保留下來
+func IndexMulti[T any, K comparable](items []T, keyFn func(T) K) map[K][]T {
+	return GroupBy(items, keyFn)
+}
@@ -0,0 +1,83 @@
+package v3
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+func TestMapHelpers(t *testing.T) {
+	ages := map[string]int{"Ann": 30, "Bob": 41, "Chris": 25}
+
+	t.Run("Keys", func(t *testing.T) {
+		got := Keys(ages)
+		slices.Sort(got)
+		want := []string{"Ann", "Bob", "Chris"}
+
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Values", func(t *testing.T) {
+		got := Values(ages)
+		slices.Sort(got)
+		want := []int{25, 30, 41}
+
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Entries", func(t *testing.T) {
+		got := Entries(ages)
+		slices.SortFunc(got, func(a, b Entry[string, int]) int {
+			return cmp.Compare(a.Key, b.Key)
+		})
+		want := []Entry[string, int]{
+			{Key: "Ann", Value: 30},
+			{Key: "Bob", Value: 41},
+			{Key: "Chris", Value: 25},
+		}
+
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("empty map", func(t *testing.T) {
+		AssertEqual(t, len(Keys(map[string]int{})), 0)
+		AssertEqual(t, len(Values(map[string]int{})), 0)
+		AssertEqual(t, len(Entries(map[string]int{})), 0)
+	})
+}
+
+func TestIndex(t *testing.T) {
+	byName := func(u User) string { return u.Name }
+
+	t.Run("unique keys", func(t *testing.T) {
+		got := Index([]User{{"Ann", 30}, {"Bob", 41}}, byName)
+
+		AssertEqual(t, len(got), 2)
+		AssertEqual(t, got["Ann"], User{"Ann", 30})
+		AssertEqual(t, got["Bob"], User{"Bob", 41})
+	})
+
+	t.Run("colliding keys keep the last item", func(t *testing.T) {
+		got := Index([]User{{"Ann", 30}, {"Bob", 41}, {"Ann", 31}}, byName)
+
+		AssertEqual(t, len(got), 2)
+		AssertEqual(t, got["Ann"], User{"Ann", 31})
+	})
+}
+
+func TestIndexMulti(t *testing.T) {
+	got := IndexMulti([]User{{"Ann", 30}, {"Bob", 41}, {"Ann", 31}}, func(u User) string { return u.Name })
+
+	if want := []User{{"Ann", 30}, {"Ann", 31}}; !slices.Equal(got["Ann"], want) {
+		t.Errorf("got %v, want %v", got["Ann"], want)
+	}
+	if want := []User{{"Bob", 41}}; !slices.Equal(got["Bob"], want) {
+		t.Errorf("got %v, want %v", got["Bob"], want)
+	}
+}
@@ -0,0 +1,85 @@
+package v3
+
+import (
+	"cmp"
+	"sync"
+)
+
+// MergeSort 回傳 xs 排序後的新 slice，不會修改 xs
+// 合併排序將 slice 對半切到只剩一個元素，再兩兩合併回來，時間複雜度 O(n log n)
+func MergeSort[T cmp.Ordered](xs []T) []T {
+	return MergeSortFunc(xs, cmp.Compare[T])
+}
+
+// MergeSortFunc 與 MergeSort 相同，但以 compare 決定順序
+// compare 與 slices.SortFunc 的約定一樣：a < b 回傳負數，a > b 回傳正數，相等回傳 0
+// 排序是穩定的，相等的元素保持原本的先後順序
+func MergeSortFunc[T any](xs []T, compare func(a, b T) int) []T {
+	if xs == nil {
+		return nil
+	}
+	sorted := CloneSlice(xs)
+	mergeSort(sorted, make([]T, len(xs)), compare)
+	return sorted
+}
+
+// ParallelMergeSort 與 MergeSort 相同，但長度超過 threshold 的區段會將兩半交給不同的 goroutine 排序
+// 區段小於等於 threshold 時改為循序排序，避免開 goroutine 的成本大於排序本身
+func ParallelMergeSort[T cmp.Ordered](xs []T, threshold int) []T {
+	if xs == nil {
+		return nil
+	}
+	sorted := CloneSlice(xs)
+	parallelMergeSort(sorted, make([]T, len(xs)), max(1, threshold), cmp.Compare[T])
+	return sorted
+}
+
+func parallelMergeSort[T any](xs, buf []T, threshold int, compare func(a, b T) int) {
+	if len(xs) <= threshold {
+		mergeSort(xs, buf, compare)
+		return
+	}
+
+	// 兩半各自使用 xs 與 buf 中不重疊的區段，因此不需要加鎖
+	mid := len(xs) / 2
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		parallelMergeSort(xs[:mid], buf[:mid], threshold, compare)
+	}()
+	parallelMergeSort(xs[mid:], buf[mid:], threshold, compare)
+	wg.Wait()
+
+	merge(xs, mid, buf, compare)
+}
+
+// mergeSort 就地排序 xs，buf 是與 xs 等長的暫存空間
+func mergeSort[T any](xs, buf []T, compare func(a, b T) int) {
+	if len(xs) <= 1 {
+		return
+	}
+
+	mid := len(xs) / 2
+	mergeSort(xs[:mid], buf[:mid], compare)
+	mergeSort(xs[mid:], buf[mid:], compare)
+	merge(xs, mid, buf, compare)
+}
+
+// merge 合併 xs[:mid] 與 xs[mid:] 兩段已排序的資料
+func merge[T any](xs []T, mid int, buf []T, compare func(a, b T) int) {
+	copy(buf, xs)
+	left, right := buf[:mid], buf[mid:len(xs)]
+
+	i, j := 0, 0
+	for k := range xs {
+		// 相等時取左邊的元素，排序才會是穩定的
+		if j >= len(right) || (i < len(left) && compare(left[i], right[j]) <= 0) {
+			xs[k] = left[i]
+			i++
+		} else {
+			xs[k] = right[j]
+			j++
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package v3
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestMergeSort(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	randomInts := make([]int, 500)
+	for i := range randomInts {
+		randomInts[i] = random.Intn(1000) - 500
+	}
+
+	cases := map[string][]int{
+		"random":          randomInts,
+		"already sorted":  {1, 2, 3, 4, 5, 6},
+		"reverse":         {6, 5, 4, 3, 2, 1},
+		"many duplicates": {3, 1, 3, 3, 2, 1, 1, 3, 2, 2},
+		"single":          {42},
+		"empty":           {},
+	}
+
+	for name, xs := range cases {
+		t.Run(name, func(t *testing.T) {
+			original := slices.Clone(xs)
+			want := slices.Clone(xs)
+			slices.Sort(want)
+
+			got := MergeSort(xs)
+
+			if !slices.Equal(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+			if !slices.Equal(xs, original) {
+				t.Error("MergeSort mutated its input")
+			}
+		})
+	}
+}
+
+func TestMergeSortFunc(t *testing.T) {
+	users := []User{{"Chris", 30}, {"Ann", 25}, {"Bob", 30}, {"Dave", 25}}
+
+	t.Run("sorts with the comparator", func(t *testing.T) {
+		got := MergeSortFunc(users, func(a, b User) int { return strings.Compare(a.Name, b.Name) })
+		want := []User{{"Ann", 25}, {"Bob", 30}, {"Chris", 30}, {"Dave", 25}}
+
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("is stable", func(t *testing.T) {
+		got := MergeSortFunc(users, func(a, b User) int { return a.Age - b.Age })
+		want := []User{{"Ann", 25}, {"Dave", 25}, {"Chris", 30}, {"Bob", 30}}
+
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+}
+
+func TestParallelMergeSort(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	xs := make([]int, 10_000)
+	for i := range xs {
+		xs[i] = random.Intn(1000)
+	}
+	want := slices.Clone(xs)
+	slices.Sort(want)
+
+	for _, threshold := range []int{0, 1, 100, len(xs)} {
+		got := ParallelMergeSort(xs, threshold)
+
+		if !slices.Equal(got, want) {
+			t.Errorf("threshold %d: result is not sorted", threshold)
+		}
+	}
+}
+
+func BenchmarkMergeSort(b *testing.B) {
+	random := rand.New(rand.NewSource(1))
+	xs := make([]int, 1_000_000)
+	for i := range xs {
+		xs[i] = random.Int()
+	}
+
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			MergeSort(xs)
+		}
+	})
+
+	b.Run("parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ParallelMergeSort(xs, 10_000)
+		}
+	})
+}
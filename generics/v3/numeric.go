@@ -0,0 +1,53 @@
+package v3
+
+import "cmp"
+
+// Number 可以做加法運算的數值型別
+// ~int 表示底層型別是 int 的自訂型別（例如 type Celsius int）也符合
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Min 回傳 xs 中最小的值，xs 為空時回傳零值與 false
+// cmp.Ordered 是標準庫提供的約束，涵蓋所有支援 < 運算的型別
+func Min[T cmp.Ordered](xs []T) (T, bool) {
+	if len(xs) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	m := xs[0]
+	for _, x := range xs[1:] {
+		if x < m {
+			m = x
+		}
+	}
+	return m, true
+}
+
+// Max 回傳 xs 中最大的值，xs 為空時回傳零值與 false
+func Max[T cmp.Ordered](xs []T) (T, bool) {
+	if len(xs) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	m := xs[0]
+	for _, x := range xs[1:] {
+		if x > m {
+			m = x
+		}
+	}
+	return m, true
+}
+
+// Sum 回傳 xs 的總和，xs 為空時回傳零值
+func Sum[T Number](xs []T) T {
+	var sum T
+	for _, x := range xs {
+		sum += x
+	}
+	return sum
+}
@@ -0,0 +1,56 @@
+package v3
+
+import "testing"
+
+func TestMinMax(t *testing.T) {
+	t.Run("ints", func(t *testing.T) {
+		xs := []int{3, 1, 4, 1, 5, 9, 2, 6}
+
+		got, ok := Min(xs)
+		AssertEqual(t, ok, true)
+		AssertEqual(t, got, 1)
+
+		got, ok = Max(xs)
+		AssertEqual(t, ok, true)
+		AssertEqual(t, got, 9)
+	})
+
+	t.Run("floats", func(t *testing.T) {
+		xs := []float64{2.5, -1.5, 3.25}
+
+		got, _ := Min(xs)
+		AssertEqual(t, got, -1.5)
+
+		got, _ = Max(xs)
+		AssertEqual(t, got, 3.25)
+	})
+
+	t.Run("empty slice", func(t *testing.T) {
+		got, ok := Min([]int{})
+		AssertEqual(t, ok, false)
+		AssertEqual(t, got, 0)
+
+		got, ok = Max[int](nil)
+		AssertEqual(t, ok, false)
+		AssertEqual(t, got, 0)
+	})
+}
+
+func TestSum(t *testing.T) {
+	t.Run("ints", func(t *testing.T) {
+		AssertEqual(t, Sum([]int{1, 2, 3, 4}), 10)
+	})
+
+	t.Run("floats", func(t *testing.T) {
+		AssertEqual(t, Sum([]float64{0.5, 0.25, 1}), 1.75)
+	})
+
+	t.Run("custom number type", func(t *testing.T) {
+		type Celsius int
+		AssertEqual(t, Sum([]Celsius{10, 20}), Celsius(30))
+	})
+
+	t.Run("empty slice", func(t *testing.T) {
+		AssertEqual(t, Sum([]int{}), 0)
+	})
+}
@@ -0,0 +1,34 @@
+package v3
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// ParallelMap 以 workers 個 goroutine 對 xs 的每個元素套用 f
+// 結果依照 xs 的順序存放；ctx 取消後不再處理新的元素，尚未處理的位置會是零值
+func ParallelMap[T, U any](ctx context.Context, xs []T, workers int, f func(T) U) []U {
+	results := make([]U, len(xs))
+	workers = max(1, min(workers, len(xs)))
+
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for range workers {
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= len(xs) || ctx.Err() != nil {
+					return
+				}
+				// 每個 goroutine 只寫入自己拿到的 index，所以不需要加鎖
+				results[i] = f(xs[i])
+			}
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,50 @@
+package v3
+
+import (
+	"context"
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+func TestParallelMap(t *testing.T) {
+	t.Run("output order matches input", func(t *testing.T) {
+		xs := make([]int, 1000)
+		want := make([]int, len(xs))
+		for i := range xs {
+			xs[i] = i
+			want[i] = i * i
+		}
+
+		got := ParallelMap(context.Background(), xs, 8, func(n int) int { return n * n })
+
+		if !slices.Equal(got, want) {
+			t.Error("results are not in input order")
+		}
+	})
+
+	t.Run("cancellation stops work early", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var calls atomic.Int32
+		xs := make([]int, 100)
+
+		// 只用一個 worker，處理完第 3 個元素後取消
+		ParallelMap(ctx, xs, 1, func(n int) int {
+			if calls.Add(1) == 3 {
+				cancel()
+			}
+			return n
+		})
+
+		AssertEqual(t, calls.Load(), int32(3))
+	})
+
+	t.Run("empty slice", func(t *testing.T) {
+		got := ParallelMap(context.Background(), []int{}, 4, func(n int) int { return n })
+		AssertEqual(t, len(got), 0)
+	})
+
+	// go test -race -run TestParallelMap ./v3
+}
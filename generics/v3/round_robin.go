@@ -0,0 +1,18 @@
+package v3
+
+import "sync/atomic"
+
+// RoundRobin 回傳一個 closure，每次呼叫依序回傳 items 的下一個元素，到底後從頭開始
+// 以 atomic 計數器決定順序，可同時從多個 goroutine 呼叫
+// items 為空時會 panic，因為沒有東西可以輪流
+func RoundRobin[T any](items []T) func() T {
+	if len(items) == 0 {
+		panic("v3: RoundRobin needs at least one item")
+	}
+
+	var next atomic.Uint64
+	return func() T {
+		i := next.Add(1) - 1
+		return items[i%uint64(len(items))]
+	}
+}
@@ -0,0 +1,48 @@
+package v3
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestRoundRobin(t *testing.T) {
+	t.Run("cycles through the items in order", func(t *testing.T) {
+		next := RoundRobin([]string{"a", "b", "c"})
+
+		for _, want := range []string{"a", "b", "c", "a", "b"} {
+			AssertEqual(t, next(), want)
+		}
+	})
+
+	t.Run("spreads concurrent calls evenly", func(t *testing.T) {
+		workers := []string{"w1", "w2", "w3", "w4"}
+		next := RoundRobin(workers)
+
+		const goroutines, calls = 8, 100
+		var (
+			mu     sync.Mutex
+			counts = make(map[string]int)
+			wg     sync.WaitGroup
+		)
+		wg.Add(goroutines)
+		for range goroutines {
+			go func() {
+				defer wg.Done()
+				for range calls {
+					w := next()
+					mu.Lock()
+					counts[w]++
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		want := goroutines * calls / len(workers)
+		for _, w := range workers {
+			if counts[w] != want {
+				t.Errorf("got %d calls for %s, want %d", counts[w], w, want)
+			}
+		}
+	})
+}
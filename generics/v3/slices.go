@@ -0,0 +1,111 @@
+package v3
+
+// GroupBy 依 keyFn 算出的 key 將 items 分組，同一組內保持原本的順序
+func GroupBy[T any, K comparable](items []T, keyFn func(T) K) map[K][]T {
+	groups := make(map[K][]T)
+	for _, item := range items {
+		key := keyFn(item)
+		groups[key] = append(groups[key], item)
+	}
+	return groups
+}
+
+// Chunk 將 xs 切成多個長度最多為 size 的子 slice，最後一段可能較短
+// 子 slice 與 xs 共用底層陣列；size <= 0 會 panic，因為那代表呼叫端的程式有錯
+func Chunk[T any](xs []T, size int) [][]T {
+	if size <= 0 {
+		panic("v3: Chunk size must be positive")
+	}
+
+	chunks := make([][]T, 0, (len(xs)+size-1)/size)
+	for start := 0; start < len(xs); start += size {
+		end := min(start+size, len(xs))
+		chunks = append(chunks, xs[start:end:end])
+	}
+	return chunks
+}
+
+// Unique 回傳移除重複元素後的新 slice，保留每個元素第一次出現的位置
+// xs 為 nil 時回傳 nil
+func Unique[T comparable](xs []T) []T {
+	if xs == nil {
+		return nil
+	}
+
+	seen := make(map[T]struct{}, len(xs))
+	unique := make([]T, 0, len(xs))
+	for _, x := range xs {
+		if _, ok := seen[x]; ok {
+			continue
+		}
+		seen[x] = struct{}{}
+		unique = append(unique, x)
+	}
+	return unique
+}
+
+// Pair 是兩個可以不同型別的值
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Zip 將 as 與 bs 相同位置的元素配成 Pair，長度以較短的為準
+func Zip[A, B any](as []A, bs []B) []Pair[A, B] {
+	n := min(len(as), len(bs))
+	pairs := make([]Pair[A, B], n)
+	for i := range n {
+		pairs[i] = Pair[A, B]{First: as[i], Second: bs[i]}
+	}
+	return pairs
+}
+
+// Partition 依 pred 將 xs 分成符合與不符合的兩組，兩組都保持原本的順序
+func Partition[T any](xs []T, pred func(T) bool) (matched, rest []T) {
+	for _, x := range xs {
+		if pred(x) {
+			matched = append(matched, x)
+		} else {
+			rest = append(rest, x)
+		}
+	}
+	return matched, rest
+}
+
+// CloneSlice 回傳 xs 的淺拷貝：新的底層陣列，但元素本身只是逐一複製
+// 元素若含有 pointer、slice 或 map，clone 與 xs 仍會指向同一份資料
+// xs 為 nil 時回傳 nil
+func CloneSlice[T any](xs []T) []T {
+	if xs == nil {
+		return nil
+	}
+	return append(make([]T, 0, len(xs)), xs...)
+}
+
+// Diff 以 keyFn 配對 old 與 new 的元素，找出新增、移除與變更的部分
+// added 與 changed 依 new 的順序並回傳新的值，removed 依 old 的順序
+// 同一個 key 在 old 與 new 都有、但 equal 回傳 false 時算是變更
+func Diff[T any, K comparable](old, new []T, keyFn func(T) K, equal func(a, b T) bool) (added, removed, changed []T) {
+	oldByKey := Index(old, keyFn)
+	newKeys := make(map[K]struct{}, len(new))
+
+	for _, n := range new {
+		key := keyFn(n)
+		newKeys[key] = struct{}{}
+
+		o, ok := oldByKey[key]
+		switch {
+		case !ok:
+			added = append(added, n)
+		case !equal(o, n):
+			changed = append(changed, n)
+		}
+	}
+
+	for _, o := range old {
+		if _, ok := newKeys[keyFn(o)]; !ok {
+			removed = append(removed, o)
+		}
+	}
+	return added, removed, changed
+}
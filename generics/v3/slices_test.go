@@ -0,0 +1,185 @@
+package v3
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+type User struct {
+	Name string
+	Age  int
+}
+
+func TestGroupBy(t *testing.T) {
+	users := []User{
+		{Name: "Ann", Age: 30},
+		{Name: "Chris", Age: 25},
+		{Name: "Bob", Age: 41},
+		{Name: "Grace", Age: 85},
+		{Name: "Ted", Age: 18},
+	}
+
+	got := GroupBy(users, func(u User) int { return len(u.Name) })
+	want := map[int][]User{
+		3: {{Name: "Ann", Age: 30}, {Name: "Bob", Age: 41}, {Name: "Ted", Age: 18}},
+		5: {{Name: "Chris", Age: 25}, {Name: "Grace", Age: 85}},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestChunk(t *testing.T) {
+	t.Run("exact multiple", func(t *testing.T) {
+		got := Chunk([]int{1, 2, 3, 4, 5, 6}, 3)
+		want := [][]int{{1, 2, 3}, {4, 5, 6}}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("with a remainder", func(t *testing.T) {
+		got := Chunk([]int{1, 2, 3, 4, 5}, 2)
+		want := [][]int{{1, 2}, {3, 4}, {5}}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("empty slice", func(t *testing.T) {
+		got := Chunk([]int{}, 3)
+		AssertEqual(t, len(got), 0)
+	})
+
+	t.Run("invalid size panics", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected Chunk to panic for size 0")
+			}
+		}()
+		Chunk([]int{1, 2, 3}, 0)
+	})
+}
+
+func TestUnique(t *testing.T) {
+	t.Run("duplicates scattered throughout", func(t *testing.T) {
+		got := Unique([]string{"b", "a", "b", "c", "a", "d", "c"})
+		want := []string{"b", "a", "c", "d"}
+
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("already unique", func(t *testing.T) {
+		xs := []int{1, 2, 3}
+		got := Unique(xs)
+
+		if !slices.Equal(got, xs) {
+			t.Errorf("got %v, want %v", got, xs)
+		}
+	})
+
+	t.Run("nil slice", func(t *testing.T) {
+		if got := Unique[int](nil); got != nil {
+			t.Errorf("got %v, want nil", got)
+		}
+	})
+}
+
+func TestZip(t *testing.T) {
+	t.Run("equal length", func(t *testing.T) {
+		got := Zip([]string{"Ann", "Bob"}, []int{30, 41})
+		want := []Pair[string, int]{{"Ann", 30}, {"Bob", 41}}
+
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("truncates to the shorter input", func(t *testing.T) {
+		got := Zip([]string{"Ann", "Bob", "Chris"}, []int{30})
+		want := []Pair[string, int]{{"Ann", 30}}
+
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		got := Zip([]string{}, []int{1, 2})
+		AssertEqual(t, len(got), 0)
+	})
+}
+
+func TestPartition(t *testing.T) {
+	isEven := func(n int) bool { return n%2 == 0 }
+
+	evens, odds := Partition([]int{5, 2, 8, 1, 4, 7, 3}, isEven)
+
+	if want := []int{2, 8, 4}; !slices.Equal(evens, want) {
+		t.Errorf("got matched %v, want %v", evens, want)
+	}
+	if want := []int{5, 1, 7, 3}; !slices.Equal(odds, want) {
+		t.Errorf("got rest %v, want %v", odds, want)
+	}
+}
+
+func TestCloneSlice(t *testing.T) {
+	t.Run("mutating the clone leaves the original unchanged", func(t *testing.T) {
+		users := []User{{Name: "Ann", Age: 30}, {Name: "Bob", Age: 25}}
+
+		clone := CloneSlice(users)
+		clone[0].Name = "Annie"
+		clone = append(clone, User{Name: "Chris", Age: 40})
+
+		want := []User{{Name: "Ann", Age: 30}, {Name: "Bob", Age: 25}}
+		if !slices.Equal(users, want) {
+			t.Errorf("got %v, want %v", users, want)
+		}
+		AssertEqual(t, len(clone), 3)
+	})
+
+	t.Run("nil stays nil", func(t *testing.T) {
+		if got := CloneSlice[User](nil); got != nil {
+			t.Errorf("got %v, want nil", got)
+		}
+	})
+}
+
+func TestDiff(t *testing.T) {
+	byName := func(u User) string { return u.Name }
+	sameUser := func(a, b User) bool { return a == b }
+
+	old := []User{{"Ann", 30}, {"Bob", 41}, {"Chris", 25}}
+	updated := []User{{"Ann", 30}, {"Chris", 26}, {"Dave", 19}}
+
+	added, removed, changed := Diff(old, updated, byName, sameUser)
+
+	t.Run("additions", func(t *testing.T) {
+		if want := []User{{"Dave", 19}}; !slices.Equal(added, want) {
+			t.Errorf("got %v, want %v", added, want)
+		}
+	})
+
+	t.Run("removals", func(t *testing.T) {
+		if want := []User{{"Bob", 41}}; !slices.Equal(removed, want) {
+			t.Errorf("got %v, want %v", removed, want)
+		}
+	})
+
+	t.Run("changes carry the new value", func(t *testing.T) {
+		if want := []User{{"Chris", 26}}; !slices.Equal(changed, want) {
+			t.Errorf("got %v, want %v", changed, want)
+		}
+	})
+
+	t.Run("unchanged lists have no differences", func(t *testing.T) {
+		added, removed, changed := Diff(old, old, byName, sameUser)
+		AssertEqual(t, len(added)+len(removed)+len(changed), 0)
+	})
+}
@@ -0,0 +1,20 @@
+package v3
+
+import (
+	"cmp"
+	"slices"
+)
+
+// InsertSorted 將 v 插入已排序的 xs 並保持排序，插入位置以二分搜尋找出
+// 與 append 一樣可能沿用 xs 的底層陣列，呼叫端應改用回傳的 slice
+func InsertSorted[T cmp.Ordered](xs []T, v T) []T {
+	i, _ := slices.BinarySearch(xs, v)
+	return slices.Insert(xs, i, v)
+}
+
+// BinarySearch 在已排序的 xs 中尋找 target
+// 找到時回傳它的索引與 true；找不到時回傳 target 應插入的位置與 false，
+// 也就是 InsertSorted 會放置它的地方
+func BinarySearch[T cmp.Ordered](xs []T, target T) (int, bool) {
+	return slices.BinarySearch(xs, target)
+}
@@ -0,0 +1,64 @@
+package v3
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestInsertSorted(t *testing.T) {
+	cases := []struct {
+		name string
+		xs   []int
+		v    int
+		want []int
+	}{
+		{"front", []int{2, 4, 6}, 1, []int{1, 2, 4, 6}},
+		{"middle", []int{2, 4, 6}, 5, []int{2, 4, 5, 6}},
+		{"end", []int{2, 4, 6}, 7, []int{2, 4, 6, 7}},
+		{"duplicate", []int{2, 4, 6}, 4, []int{2, 4, 4, 6}},
+		{"empty", nil, 3, []int{3}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := InsertSorted(c.xs, c.v)
+			if !slices.Equal(got, c.want) {
+				t.Errorf("got %v, want %v", got, c.want)
+			}
+		})
+	}
+
+	t.Run("strings", func(t *testing.T) {
+		got := InsertSorted([]string{"Ann", "Chris"}, "Bob")
+		want := []string{"Ann", "Bob", "Chris"}
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+}
+
+func TestBinarySearch(t *testing.T) {
+	xs := []int{1, 3, 5, 7}
+
+	t.Run("found", func(t *testing.T) {
+		i, found := BinarySearch(xs, 5)
+		AssertEqual(t, i, 2)
+		AssertEqual(t, found, true)
+	})
+
+	t.Run("not found returns the insertion index", func(t *testing.T) {
+		i, found := BinarySearch(xs, 4)
+		AssertEqual(t, i, 2)
+		AssertEqual(t, found, false)
+
+		i, found = BinarySearch(xs, 9)
+		AssertEqual(t, i, len(xs))
+		AssertEqual(t, found, false)
+	})
+
+	t.Run("empty slice", func(t *testing.T) {
+		i, found := BinarySearch([]int{}, 4)
+		AssertEqual(t, i, 0)
+		AssertEqual(t, found, false)
+	})
+}
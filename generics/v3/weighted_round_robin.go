@@ -0,0 +1,64 @@
+package v3
+
+import (
+	"errors"
+	"sync"
+)
+
+var (
+	// ErrWeightsMismatch 表示 items 與 weights 長度不同或為空
+	ErrWeightsMismatch = errors.New("v3: items and weights must have the same, non-zero length")
+	// ErrWeightNotPositive 表示有權重小於等於 0
+	ErrWeightNotPositive = errors.New("v3: weights must be positive")
+)
+
+// WeightedRoundRobin 依權重輪流回傳元素，權重為 3 的元素被選中的次數是權重為 1 的三倍
+// 使用 nginx 的 smooth weighted round-robin：高權重的元素會分散在序列中，而不是連續出現
+// 可同時從多個 goroutine 呼叫 Next
+type WeightedRoundRobin[T any] struct {
+	mu      sync.Mutex
+	items   []T
+	weights []int
+	current []int
+	total   int
+}
+
+// NewWeightedRoundRobin 以 items 與對應的 weights 建立選擇器
+// 長度不同或為空時回傳 ErrWeightsMismatch，有權重 <= 0 時回傳 ErrWeightNotPositive
+func NewWeightedRoundRobin[T any](items []T, weights []int) (*WeightedRoundRobin[T], error) {
+	if len(items) == 0 || len(items) != len(weights) {
+		return nil, ErrWeightsMismatch
+	}
+
+	total := 0
+	for _, w := range weights {
+		if w <= 0 {
+			return nil, ErrWeightNotPositive
+		}
+		total += w
+	}
+
+	return &WeightedRoundRobin[T]{
+		items:   CloneSlice(items),
+		weights: CloneSlice(weights),
+		current: make([]int, len(items)),
+		total:   total,
+	}, nil
+}
+
+// Next 回傳下一個元素
+// 每一輪所有元素的 current 加上自己的權重，選出 current 最大的，再將它減去權重總和
+func (w *WeightedRoundRobin[T]) Next() T {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	best := 0
+	for i, weight := range w.weights {
+		w.current[i] += weight
+		if w.current[i] > w.current[best] {
+			best = i
+		}
+	}
+	w.current[best] -= w.total
+	return w.items[best]
+}
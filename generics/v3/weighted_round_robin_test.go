@@ -0,0 +1,57 @@
+package v3
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestWeightedRoundRobin(t *testing.T) {
+	t.Run("picks in proportion to the weights", func(t *testing.T) {
+		backends := []string{"big", "medium", "small"}
+		weights := []int{5, 3, 2}
+		wrr, err := NewWeightedRoundRobin(backends, weights)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		const picks = 10_000
+		counts := make(map[string]int)
+		for range picks {
+			counts[wrr.Next()]++
+		}
+
+		for i, backend := range backends {
+			want := picks * weights[i] / 10
+			if diff := counts[backend] - want; diff < -picks/100 || diff > picks/100 {
+				t.Errorf("got %d picks for %s, want about %d", counts[backend], backend, want)
+			}
+		}
+	})
+
+	t.Run("spreads a heavy item out rather than repeating it", func(t *testing.T) {
+		wrr, _ := NewWeightedRoundRobin([]string{"a", "b", "c"}, []int{5, 1, 1})
+
+		var got []string
+		for range 7 {
+			got = append(got, wrr.Next())
+		}
+
+		want := []string{"a", "a", "b", "a", "c", "a", "a"}
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("rejects invalid weights", func(t *testing.T) {
+		if _, err := NewWeightedRoundRobin([]string{"a", "b"}, []int{1}); !errors.Is(err, ErrWeightsMismatch) {
+			t.Errorf("got %v, want %v", err, ErrWeightsMismatch)
+		}
+		if _, err := NewWeightedRoundRobin([]string{}, []int{}); !errors.Is(err, ErrWeightsMismatch) {
+			t.Errorf("got %v, want %v", err, ErrWeightsMismatch)
+		}
+		if _, err := NewWeightedRoundRobin([]string{"a", "b"}, []int{1, 0}); !errors.Is(err, ErrWeightNotPositive) {
+			t.Errorf("got %v, want %v", err, ErrWeightNotPositive)
+		}
+	})
+}
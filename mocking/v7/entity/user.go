@@ -1,10 +1,32 @@
 package entity
 
 import (
+	"errors"
+	"time"
+
 	"github.com/google/uuid"
 )
 
+var (
+	ErrMissingID   = errors.New("user id is required")
+	ErrMissingName = errors.New("user name is required")
+)
+
 type User struct {
-	Id   uuid.UUID
-	Name string
+	Id        uuid.UUID
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Validate reports every field of u that is invalid.
+func (u User) Validate() error {
+	var errs []error
+	if u.Id == uuid.Nil {
+		errs = append(errs, ErrMissingID)
+	}
+	if u.Name == "" {
+		errs = append(errs, ErrMissingName)
+	}
+	return errors.Join(errs...)
 }
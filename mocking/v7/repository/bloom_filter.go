@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+)
+
+// BloomFilter answers "definitely not present" or "maybe present" for byte
+// keys using a fixed amount of memory. It never returns a false negative.
+// It is not safe for concurrent use.
+type BloomFilter struct {
+	bits   []uint64
+	m      uint64
+	hashes uint64
+}
+
+// NewBloomFilter sizes a filter so that after expectedItems additions
+// MightContain returns true for roughly falsePositiveRate of absent keys.
+// It panics if expectedItems < 1 or falsePositiveRate is not in (0, 1).
+func NewBloomFilter(expectedItems int, falsePositiveRate float64) *BloomFilter {
+	if expectedItems < 1 {
+		panic(fmt.Sprintf("bloom filter: expectedItems must be at least 1, got %d", expectedItems))
+	}
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		panic(fmt.Sprintf("bloom filter: falsePositiveRate must be in (0, 1), got %v", falsePositiveRate))
+	}
+
+	n := float64(expectedItems)
+	m := math.Ceil(-n * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	k := math.Max(1, math.Round(m/n*math.Ln2))
+
+	return &BloomFilter{
+		bits:   make([]uint64, (uint64(m)+63)/64),
+		m:      uint64(m),
+		hashes: uint64(k),
+	}
+}
+
+func (b *BloomFilter) Add(data []byte) {
+	h1, h2 := bloomHashes(data)
+	for i := range b.hashes {
+		bit := (h1 + i*h2) % b.m
+		b.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+// MightContain reports false only if data was never added.
+func (b *BloomFilter) MightContain(data []byte) bool {
+	h1, h2 := bloomHashes(data)
+	for i := range b.hashes {
+		bit := (h1 + i*h2) % b.m
+		if b.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// bloomHashes derives the two base hashes that every bit position is built
+// from (double hashing), so data is only hashed once per call.
+func bloomHashes(data []byte) (uint64, uint64) {
+	h := fnv.New64a()
+	h.Write(data)
+	h1 := h.Sum64()
+	h.Write([]byte{0})
+	h2 := h.Sum64() | 1 // odd, so it never collapses to a single position
+	return h1, h2
+}
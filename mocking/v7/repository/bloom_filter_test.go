@@ -0,0 +1,60 @@
+package repository_test
+
+import (
+	"demo/repository"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBloomFilter(t *testing.T) {
+	t.Run("should never miss an added item", func(t *testing.T) {
+		// Arrange
+		filter := repository.NewBloomFilter(1000, 0.01)
+		ids := make([]uuid.UUID, 1000)
+		for i := range ids {
+			ids[i] = uuid.New()
+		}
+
+		// Act
+		for _, id := range ids {
+			filter.Add(id[:])
+		}
+
+		// Assert
+		for _, id := range ids {
+			assert.True(t, filter.MightContain(id[:]), "false negative for %s", id)
+		}
+	})
+
+	t.Run("should keep false positives near the configured rate", func(t *testing.T) {
+		// Arrange
+		const rate = 0.01
+		filter := repository.NewBloomFilter(1000, rate)
+		for range 1000 {
+			id := uuid.New()
+			filter.Add(id[:])
+		}
+
+		// Act
+		const queries = 100_000
+		falsePositives := 0
+		for range queries {
+			id := uuid.New()
+			if filter.MightContain(id[:]) {
+				falsePositives++
+			}
+		}
+
+		// Assert
+		got := float64(falsePositives) / queries
+		assert.Less(t, got, rate*2, "false positive rate %v", got)
+	})
+
+	t.Run("should panic on invalid parameters", func(t *testing.T) {
+		assert.Panics(t, func() { repository.NewBloomFilter(0, 0.01) })
+		assert.Panics(t, func() { repository.NewBloomFilter(10, 0) })
+		assert.Panics(t, func() { repository.NewBloomFilter(10, 1) })
+	})
+}
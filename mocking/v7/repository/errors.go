@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"demo/entity"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// ErrUserNotFound is returned when the requested user doesn't exist.
+var ErrUserNotFound = errors.New("user not found")
+
+// translatedError marks an error that has already been through TranslateErrors,
+// so calls made inside a translated Transaction aren't wrapped twice.
+type translatedError struct {
+	err error
+}
+
+func (e *translatedError) Error() string { return e.err.Error() }
+func (e *translatedError) Unwrap() error { return e.err }
+
+type errorTranslator struct {
+	inner    IUserRepository
+	mappings map[error]error
+}
+
+// TranslateErrors wraps inner so errors matching a key of mappings (with
+// errors.Is) come back as the domain error it maps to, e.g.
+//
+//	repository.TranslateErrors(repo, map[error]error{sql.ErrNoRows: repository.ErrUserNotFound})
+//
+// The inner error is kept in the message only. Any other error is wrapped
+// as is, so errors.Is still matches it.
+func TranslateErrors(inner IUserRepository, mappings map[error]error) IUserRepository {
+	return &errorTranslator{
+		inner:    inner,
+		mappings: mappings,
+	}
+}
+
+func (r *errorTranslator) Transaction(ctx context.Context, fn func(context.Context) error) error {
+	return r.translate(r.inner.Transaction(ctx, fn))
+}
+
+func (r *errorTranslator) CreateUser(ctx context.Context, user *entity.User) error {
+	return r.translate(r.inner.CreateUser(ctx, user))
+}
+
+func (r *errorTranslator) GetUser(ctx context.Context, user *entity.User) error {
+	return r.translate(r.inner.GetUser(ctx, user))
+}
+
+func (r *errorTranslator) UpdateUsers(ctx context.Context, users []entity.User) error {
+	return r.translate(r.inner.UpdateUsers(ctx, users))
+}
+
+func (r *errorTranslator) ListUsers(ctx context.Context) ([]entity.User, error) {
+	users, err := r.inner.ListUsers(ctx)
+	return users, r.translate(err)
+}
+
+func (r *errorTranslator) DeleteUser(ctx context.Context, id uuid.UUID) error {
+	return r.translate(r.inner.DeleteUser(ctx, id))
+}
+
+func (r *errorTranslator) translate(err error) error {
+	if err == nil {
+		return nil
+	}
+	var done *translatedError
+	if errors.As(err, &done) {
+		return err
+	}
+
+	for from, to := range r.mappings {
+		if errors.Is(err, from) {
+			return &translatedError{fmt.Errorf("%w: %v", to, err)}
+		}
+	}
+	return &translatedError{fmt.Errorf("user repository: %w", err)}
+}
@@ -0,0 +1,85 @@
+package repository_test
+
+import (
+	"context"
+	"demo/entity"
+	"demo/repository"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTranslateErrors(t *testing.T) {
+	errNoRows := errors.New("sql: no rows in result set")
+
+	t.Run("should map a known inner error to ErrUserNotFound", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repository.NewMockIUserRepository(ctrl)
+		mockRepo.EXPECT().
+			GetUser(gomock.Any(), gomock.Any()).
+			Return(errNoRows)
+
+		repo := repository.TranslateErrors(mockRepo, map[error]error{errNoRows: repository.ErrUserNotFound})
+
+		// Act
+		err := repo.GetUser(context.Background(), &entity.User{Id: uuid.New()})
+
+		// Assert
+		assert.ErrorIs(t, err, repository.ErrUserNotFound)
+		assert.NotErrorIs(t, err, errNoRows)
+	})
+
+	t.Run("should wrap an unknown inner error", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("connection reset")
+		mockRepo := repository.NewMockIUserRepository(ctrl)
+		mockRepo.EXPECT().
+			UpdateUsers(gomock.Any(), gomock.Any()).
+			Return(expectedErr)
+
+		repo := repository.TranslateErrors(mockRepo, map[error]error{errNoRows: repository.ErrUserNotFound})
+
+		// Act
+		err := repo.UpdateUsers(context.Background(), []entity.User{{Id: uuid.New(), Name: "User"}})
+
+		// Assert
+		assert.ErrorIs(t, err, expectedErr)
+		assert.NotErrorIs(t, err, repository.ErrUserNotFound)
+	})
+
+	t.Run("should not wrap errors twice inside a transaction", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repository.NewMockIUserRepository(ctrl)
+		repo := repository.TranslateErrors(mockRepo, map[error]error{errNoRows: repository.ErrUserNotFound})
+
+		mockRepo.EXPECT().
+			Transaction(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, txFn func(context.Context) error) error {
+				return txFn(ctx)
+			})
+		mockRepo.EXPECT().
+			GetUser(gomock.Any(), gomock.Any()).
+			Return(errNoRows)
+
+		// Act
+		err := repo.Transaction(context.Background(), func(ctx context.Context) error {
+			return repo.GetUser(ctx, &entity.User{Id: uuid.New()})
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, repository.ErrUserNotFound)
+		assert.Equal(t, "user not found: "+errNoRows.Error(), err.Error())
+	})
+}
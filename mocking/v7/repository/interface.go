@@ -3,11 +3,16 @@ package repository
 import (
 	"context"
 	"demo/entity"
+
+	"github.com/google/uuid"
 )
 
 type IUserRepository interface {
 	// db tranction
 	Transaction(context.Context, func(context.Context) error) error
+	CreateUser(context.Context, *entity.User) error
 	GetUser(context.Context, *entity.User) error
 	UpdateUsers(context.Context, []entity.User) error
+	ListUsers(context.Context) ([]entity.User, error)
+	DeleteUser(context.Context, uuid.UUID) error
 }
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"maps"
+	"slices"
+	"sync"
+)
+
+// InMemoryStore is a concurrency-safe map that in-memory repositories can be
+// built on, whatever the entity.
+type InMemoryStore[K comparable, V any] struct {
+	mu    sync.RWMutex
+	txMu  sync.Mutex
+	items map[K]V
+}
+
+func NewInMemoryStore[K comparable, V any]() *InMemoryStore[K, V] {
+	return &InMemoryStore[K, V]{items: make(map[K]V)}
+}
+
+func (s *InMemoryStore[K, V]) Get(key K) (V, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.items[key]
+	return v, ok
+}
+
+func (s *InMemoryStore[K, V]) Put(key K, value V) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items[key] = value
+}
+
+func (s *InMemoryStore[K, V]) Delete(key K) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.items, key)
+}
+
+// All returns every value in no particular order.
+func (s *InMemoryStore[K, V]) All() []V {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Collect(maps.Values(s.items))
+}
+
+// Transaction runs fn and, if it returns an error, puts the store back the way
+// it was before fn started. Transactions run one at a time; writes made
+// outside a transaction while one is running are rolled back with it.
+// Values are snapshotted shallowly, so pointer values must not be mutated in place.
+func (s *InMemoryStore[K, V]) Transaction(fn func() error) error {
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	s.mu.RLock()
+	snapshot := maps.Clone(s.items)
+	s.mu.RUnlock()
+
+	if err := fn(); err != nil {
+		s.mu.Lock()
+		s.items = snapshot
+		s.mu.Unlock()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package repository_test
+
+import (
+	"demo/repository"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryStore(t *testing.T) {
+	t.Run("should get, put and delete values", func(t *testing.T) {
+		// Arrange
+		store := repository.NewInMemoryStore[int, string]()
+
+		// Act
+		store.Put(1, "Ann")
+		store.Put(2, "Bob")
+		store.Delete(2)
+
+		// Assert
+		got, ok := store.Get(1)
+		assert.True(t, ok)
+		assert.Equal(t, "Ann", got)
+
+		_, ok = store.Get(2)
+		assert.False(t, ok)
+		assert.Equal(t, []string{"Ann"}, store.All())
+	})
+
+	t.Run("should keep the writes of a successful transaction", func(t *testing.T) {
+		// Arrange
+		store := repository.NewInMemoryStore[int, string]()
+
+		// Act
+		err := store.Transaction(func() error {
+			store.Put(1, "Ann")
+			return nil
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		got, ok := store.Get(1)
+		assert.True(t, ok)
+		assert.Equal(t, "Ann", got)
+	})
+
+	t.Run("should roll back a failed transaction", func(t *testing.T) {
+		// Arrange
+		store := repository.NewInMemoryStore[int, string]()
+		store.Put(1, "Ann")
+		store.Put(2, "Bob")
+		expectedErr := errors.New("transaction error")
+
+		// Act
+		err := store.Transaction(func() error {
+			store.Put(1, "Annie")
+			store.Delete(2)
+			store.Put(3, "Chris")
+			return expectedErr
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, expectedErr)
+		assert.ElementsMatch(t, []string{"Ann", "Bob"}, store.All())
+	})
+
+	t.Run("should be safe for concurrent use", func(t *testing.T) {
+		// Arrange
+		store := repository.NewInMemoryStore[int, string]()
+		var wg sync.WaitGroup
+
+		// Act
+		for i := range 50 {
+			wg.Add(3)
+			go func() {
+				defer wg.Done()
+				_ = store.Transaction(func() error {
+					store.Put(i, fmt.Sprint(i))
+					return nil
+				})
+			}()
+			go func() {
+				defer wg.Done()
+				store.Get(i)
+				store.All()
+			}()
+			go func() {
+				defer wg.Done()
+				_ = store.Transaction(func() error {
+					store.Put(-1, "rolled back")
+					return errors.New("rollback")
+				})
+			}()
+		}
+		wg.Wait()
+
+		// Assert
+		assert.Len(t, store.All(), 50)
+		_, ok := store.Get(-1)
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"demo/entity"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// InMemoryUserRepository is an IUserRepository kept in memory, for tests
+// and demos that want a real repository rather than a mock.
+type InMemoryUserRepository struct {
+	store *InMemoryStore[uuid.UUID, entity.User]
+}
+
+func NewInMemoryUserRepository() *InMemoryUserRepository {
+	return &InMemoryUserRepository{store: NewInMemoryStore[uuid.UUID, entity.User]()}
+}
+
+// Transaction rolls back every write fn made if fn returns an error.
+// Transactions can't be nested.
+func (r *InMemoryUserRepository) Transaction(ctx context.Context, fn func(context.Context) error) error {
+	return r.store.Transaction(func() error {
+		return fn(ctx)
+	})
+}
+
+func (r *InMemoryUserRepository) CreateUser(_ context.Context, user *entity.User) error {
+	r.store.Put(user.Id, *user)
+	return nil
+}
+
+func (r *InMemoryUserRepository) GetUser(_ context.Context, user *entity.User) error {
+	found, ok := r.store.Get(user.Id)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUserNotFound, user.Id)
+	}
+	*user = found
+	return nil
+}
+
+// UpdateUsers fails without writing anything if any of users doesn't exist.
+func (r *InMemoryUserRepository) UpdateUsers(_ context.Context, users []entity.User) error {
+	for _, user := range users {
+		if _, ok := r.store.Get(user.Id); !ok {
+			return fmt.Errorf("%w: %s", ErrUserNotFound, user.Id)
+		}
+	}
+	for _, user := range users {
+		r.store.Put(user.Id, user)
+	}
+	return nil
+}
+
+func (r *InMemoryUserRepository) ListUsers(context.Context) ([]entity.User, error) {
+	return r.store.All(), nil
+}
+
+func (r *InMemoryUserRepository) DeleteUser(_ context.Context, id uuid.UUID) error {
+	if _, ok := r.store.Get(id); !ok {
+		return fmt.Errorf("%w: %s", ErrUserNotFound, id)
+	}
+	r.store.Delete(id)
+	return nil
+}
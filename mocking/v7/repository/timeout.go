@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"demo/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// timeoutRepository bounds every call to the inner repository by a timeout.
+type timeoutRepository struct {
+	inner   IUserRepository
+	timeout time.Duration
+}
+
+// NewTimeoutRepository wraps inner so each call gets a context.WithTimeout of d.
+// If inner overruns, the call returns context.DeadlineExceeded after d even
+// if inner ignores its context; in that case inner may still be running, so
+// arguments such as *entity.User must not be reused until it returns.
+func NewTimeoutRepository(inner IUserRepository, d time.Duration) IUserRepository {
+	return &timeoutRepository{
+		inner:   inner,
+		timeout: d,
+	}
+}
+
+func (r *timeoutRepository) Transaction(ctx context.Context, fn func(context.Context) error) error {
+	return r.withTimeout(ctx, func(ctx context.Context) error {
+		return r.inner.Transaction(ctx, fn)
+	})
+}
+
+func (r *timeoutRepository) CreateUser(ctx context.Context, user *entity.User) error {
+	return r.withTimeout(ctx, func(ctx context.Context) error {
+		return r.inner.CreateUser(ctx, user)
+	})
+}
+
+func (r *timeoutRepository) GetUser(ctx context.Context, user *entity.User) error {
+	return r.withTimeout(ctx, func(ctx context.Context) error {
+		return r.inner.GetUser(ctx, user)
+	})
+}
+
+func (r *timeoutRepository) UpdateUsers(ctx context.Context, users []entity.User) error {
+	return r.withTimeout(ctx, func(ctx context.Context) error {
+		return r.inner.UpdateUsers(ctx, users)
+	})
+}
+
+func (r *timeoutRepository) ListUsers(ctx context.Context) ([]entity.User, error) {
+	var users []entity.User
+	err := r.withTimeout(ctx, func(ctx context.Context) error {
+		var err error
+		users, err = r.inner.ListUsers(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *timeoutRepository) DeleteUser(ctx context.Context, id uuid.UUID) error {
+	return r.withTimeout(ctx, func(ctx context.Context) error {
+		return r.inner.DeleteUser(ctx, id)
+	})
+}
+
+func (r *timeoutRepository) withTimeout(ctx context.Context, call func(context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- call(ctx)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,74 @@
+package repository_test
+
+import (
+	"context"
+	"demo/entity"
+	"demo/repository"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTimeoutRepository(t *testing.T) {
+	t.Run("should return deadline exceeded when inner call overruns", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			inner := repository.NewMockIUserRepository(ctrl)
+			release := make(chan struct{})
+			defer close(release)
+
+			// mocking a GetUser that ignores its context and hangs
+			inner.EXPECT().
+				GetUser(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ *entity.User) error {
+					<-release
+					return nil
+				})
+
+			repo := repository.NewTimeoutRepository(inner, 100*time.Millisecond)
+			start := time.Now()
+
+			// Act
+			err := repo.GetUser(context.Background(), &entity.User{Id: uuid.New()})
+
+			// Assert
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+			assert.Equal(t, 100*time.Millisecond, time.Since(start))
+		})
+	})
+
+	t.Run("should pass through calls that finish in time", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			inner := repository.NewMockIUserRepository(ctrl)
+			users := []entity.User{{Id: uuid.New(), Name: "User 1"}}
+
+			inner.EXPECT().
+				UpdateUsers(gomock.Any(), users).
+				DoAndReturn(func(ctx context.Context, _ []entity.User) error {
+					deadline, ok := ctx.Deadline()
+					assert.True(t, ok)
+					assert.Equal(t, time.Now().Add(100*time.Millisecond), deadline)
+					return nil
+				})
+
+			repo := repository.NewTimeoutRepository(inner, 100*time.Millisecond)
+
+			// Act
+			err := repo.UpdateUsers(context.Background(), users)
+
+			// Assert
+			assert.NoError(t, err)
+		})
+	})
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+	"demo/entity"
+
+	"github.com/google/uuid"
+)
+
+// Cache keeps users read from the repository so reads survive repository
+// outages. Entries past their freshness window are still returned, flagged
+// as not fresh, so they can be served when the repository is down.
+type Cache interface {
+	Get(ctx context.Context, id uuid.UUID) (user entity.User, fresh bool, ok bool)
+	Set(ctx context.Context, user entity.User)
+}
+
+// nopCache always misses; it's used when no cache is configured.
+type nopCache struct{}
+
+func (nopCache) Get(context.Context, uuid.UUID) (entity.User, bool, bool) {
+	return entity.User{}, false, false
+}
+
+func (nopCache) Set(context.Context, entity.User) {}
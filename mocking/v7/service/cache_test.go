@@ -0,0 +1,121 @@
+package service_test
+
+import (
+	"context"
+	"demo/entity"
+	"demo/repository"
+	"demo/service"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetUserResilient(t *testing.T) {
+	t.Run("should serve a fresh cache hit without calling the repository", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repository.NewMockIUserRepository(ctrl)
+		mockCache := service.NewMockCache(ctrl)
+		cached := entity.User{Id: uuid.New(), Name: "John Doe"}
+
+		mockCache.EXPECT().
+			Get(gomock.Any(), cached.Id).
+			Return(cached, true, true)
+		mockRepo.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+
+		userService := service.New(mockRepo, service.WithCache(mockCache))
+
+		// Act
+		user, stale, err := userService.GetUserResilient(context.Background(), cached.Id)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, stale)
+		assert.Equal(t, cached, user)
+	})
+
+	t.Run("should read the repository and populate the cache on a miss", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repository.NewMockIUserRepository(ctrl)
+		mockCache := service.NewMockCache(ctrl)
+		expectedUser := entity.User{Id: uuid.New(), Name: "John Doe"}
+
+		mockCache.EXPECT().
+			Get(gomock.Any(), expectedUser.Id).
+			Return(entity.User{}, false, false)
+		mockRepo.EXPECT().
+			GetUser(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, user *entity.User) error {
+				user.Name = expectedUser.Name
+				return nil
+			})
+		mockCache.EXPECT().Set(gomock.Any(), expectedUser)
+
+		userService := service.New(mockRepo, service.WithCache(mockCache))
+
+		// Act
+		user, stale, err := userService.GetUserResilient(context.Background(), expectedUser.Id)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, stale)
+		assert.Equal(t, expectedUser, user)
+	})
+
+	t.Run("should return the stale cache value when the repository fails", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repository.NewMockIUserRepository(ctrl)
+		mockCache := service.NewMockCache(ctrl)
+		cached := entity.User{Id: uuid.New(), Name: "John Doe"}
+
+		mockCache.EXPECT().
+			Get(gomock.Any(), cached.Id).
+			Return(cached, false, true)
+		mockRepo.EXPECT().
+			GetUser(gomock.Any(), gomock.Any()).
+			Return(errors.New("database error"))
+
+		userService := service.New(mockRepo, service.WithCache(mockCache))
+
+		// Act
+		user, stale, err := userService.GetUserResilient(context.Background(), cached.Id)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, stale)
+		assert.Equal(t, cached, user)
+	})
+
+	t.Run("should return the repository error when nothing is cached", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repository.NewMockIUserRepository(ctrl)
+		expectedErr := errors.New("database error")
+
+		mockRepo.EXPECT().
+			GetUser(gomock.Any(), gomock.Any()).
+			Return(expectedErr)
+
+		userService := service.New(mockRepo)
+
+		// Act
+		_, stale, err := userService.GetUserResilient(context.Background(), uuid.New())
+
+		// Assert
+		assert.ErrorIs(t, err, expectedErr)
+		assert.False(t, stale)
+	})
+}
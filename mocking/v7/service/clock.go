@@ -0,0 +1,15 @@
+package service
+
+import "time"
+
+// Clock tells the service what time it is, so tests can fix the timestamps
+// it writes.
+type Clock interface {
+	Now() time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"demo/entity"
+	"errors"
+	"slices"
+	"sync"
+)
+
+// FailureMode decides what UpdateUsersConcurrent does when a write fails.
+type FailureMode int
+
+const (
+	// FailFast cancels the writes that haven't finished yet.
+	FailFast FailureMode = iota
+	// CollectAll carries on and reports every failed write.
+	CollectAll
+)
+
+// UpdateUsersConcurrent is UpdateUsers with one write per user, spread over
+// at most concurrency goroutines inside a single transaction.
+// Failed writes are returned as joined *UserUpdateError values, ordered by index.
+func (u *UserService) UpdateUsersConcurrent(ctx context.Context, users []entity.User, concurrency int, mode FailureMode) error {
+	if err := validateUsers(users); err != nil {
+		return err
+	}
+
+	now := u.clock.Now()
+	for i := range users {
+		users[i].UpdatedAt = now
+	}
+
+	return u.repo.Transaction(ctx, func(ctx context.Context) error {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		var (
+			wg   sync.WaitGroup
+			mu   sync.Mutex
+			errs []*UserUpdateError
+		)
+		sem := make(chan struct{}, max(1, concurrency))
+
+	dispatch:
+		for i := range users {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				break dispatch
+			}
+			if ctx.Err() != nil {
+				<-sem
+				break
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				if err := u.repo.UpdateUsers(ctx, users[i:i+1]); err != nil {
+					mu.Lock()
+					errs = append(errs, &UserUpdateError{Index: i, Err: err})
+					mu.Unlock()
+					if mode == FailFast {
+						cancel()
+					}
+				}
+			}()
+		}
+		wg.Wait()
+
+		if len(errs) == 0 {
+			return ctx.Err() // only set if the caller's context was cancelled
+		}
+
+		slices.SortFunc(errs, func(a, b *UserUpdateError) int {
+			return cmp.Compare(a.Index, b.Index)
+		})
+		joined := make([]error, len(errs))
+		for i, err := range errs {
+			joined[i] = err
+		}
+		return errors.Join(joined...)
+	})
+}
@@ -0,0 +1,125 @@
+package service_test
+
+import (
+	"context"
+	"demo/entity"
+	"demo/repository"
+	"demo/service"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUpdateUsersConcurrent(t *testing.T) {
+	newUsers := func(n int) []entity.User {
+		users := make([]entity.User, n)
+		for i := range users {
+			users[i] = entity.User{Id: uuid.New(), Name: "User"}
+		}
+		return users
+	}
+
+	// mockTransaction runs the transaction body with the caller's context
+	mockTransaction := func(mockRepo *repository.MockIUserRepository) {
+		mockRepo.EXPECT().
+			Transaction(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, txFn func(context.Context) error) error {
+				return txFn(ctx)
+			})
+	}
+
+	t.Run("should stop remaining writes on the first failure in fail-fast mode", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := repository.NewMockIUserRepository(ctrl)
+			users := newUsers(4)
+			expectedErr := errors.New("update error")
+			mockTransaction(mockRepo)
+
+			var written sync.Map
+			mockRepo.EXPECT().
+				UpdateUsers(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, us []entity.User) error {
+					time.Sleep(time.Second)
+					written.Store(us[0].Id, true)
+					if us[0].Id == users[1].Id {
+						return expectedErr
+					}
+					return nil
+				}).
+				AnyTimes()
+
+			userService := service.New(mockRepo)
+
+			// Act
+			err := userService.UpdateUsersConcurrent(context.Background(), users, 1, service.FailFast)
+
+			// Assert
+			assert.ErrorIs(t, err, expectedErr)
+			var updateErr *service.UserUpdateError
+			assert.ErrorAs(t, err, &updateErr)
+			assert.Equal(t, 1, updateErr.Index)
+
+			for _, user := range users[2:] {
+				_, ok := written.Load(user.Id)
+				assert.False(t, ok, "users after the failure should not be written")
+			}
+		})
+	})
+
+	t.Run("should report every failure in collect-all mode", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := repository.NewMockIUserRepository(ctrl)
+			users := newUsers(4)
+			expectedErr := errors.New("update error")
+			mockTransaction(mockRepo)
+
+			var inFlight, maxInFlight atomic.Int32
+			mockRepo.EXPECT().
+				UpdateUsers(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, us []entity.User) error {
+					now := inFlight.Add(1)
+					if now > maxInFlight.Load() {
+						maxInFlight.Store(now)
+					}
+					time.Sleep(time.Second)
+					inFlight.Add(-1)
+
+					if us[0].Id == users[0].Id || us[0].Id == users[3].Id {
+						return expectedErr
+					}
+					return nil
+				}).
+				Times(4)
+
+			userService := service.New(mockRepo)
+
+			// Act
+			err := userService.UpdateUsersConcurrent(context.Background(), users, 2, service.CollectAll)
+
+			// Assert
+			assert.ErrorIs(t, err, expectedErr)
+			var errs interface{ Unwrap() []error }
+			assert.ErrorAs(t, err, &errs)
+			failed := errs.Unwrap()
+			assert.Len(t, failed, 2)
+			assert.Equal(t, 0, failed[0].(*service.UserUpdateError).Index)
+			assert.Equal(t, 3, failed[1].(*service.UserUpdateError).Index)
+			assert.Equal(t, int32(2), maxInFlight.Load())
+		})
+	})
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+)
+
+// InvalidUserError says which user in a batch failed validation and why.
+type InvalidUserError struct {
+	Index int
+	Err   error
+}
+
+func (e *InvalidUserError) Error() string {
+	return fmt.Sprintf("users[%d]: %v", e.Index, e.Err)
+}
+
+func (e *InvalidUserError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors collects an InvalidUserError for every invalid user,
+// so callers can fix the whole batch at once rather than one user at a time.
+type ValidationErrors []error
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return "invalid users: " + strings.Join(msgs, "; ")
+}
+
+func (e ValidationErrors) Unwrap() []error {
+	return e
+}
+
+// UserUpdateError says which user in a concurrent update failed to save.
+type UserUpdateError struct {
+	Index int
+	Err   error
+}
+
+func (e *UserUpdateError) Error() string {
+	return fmt.Sprintf("update users[%d]: %v", e.Index, e.Err)
+}
+
+func (e *UserUpdateError) Unwrap() error {
+	return e.Err
+}
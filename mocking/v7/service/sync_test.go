@@ -0,0 +1,118 @@
+package service_test
+
+import (
+	"context"
+	"demo/entity"
+	"demo/repository"
+	"demo/service"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// spyRepo records which users reach the write path of the repository it wraps.
+type spyRepo struct {
+	repository.IUserRepository
+	created, updated, deleted []uuid.UUID
+	deleteErr                 error
+}
+
+func (s *spyRepo) CreateUser(ctx context.Context, user *entity.User) error {
+	s.created = append(s.created, user.Id)
+	return s.IUserRepository.CreateUser(ctx, user)
+}
+
+func (s *spyRepo) UpdateUsers(ctx context.Context, users []entity.User) error {
+	for _, user := range users {
+		s.updated = append(s.updated, user.Id)
+	}
+	return s.IUserRepository.UpdateUsers(ctx, users)
+}
+
+func (s *spyRepo) DeleteUser(ctx context.Context, id uuid.UUID) error {
+	s.deleted = append(s.deleted, id)
+	if s.deleteErr != nil {
+		return s.deleteErr
+	}
+	return s.IUserRepository.DeleteUser(ctx, id)
+}
+
+func TestSyncUsers(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	// seed fills an in-memory repository with ann, bob and chris
+	seed := func(t *testing.T) (*spyRepo, entity.User, entity.User, entity.User) {
+		t.Helper()
+		repo := repository.NewInMemoryUserRepository()
+		ann := entity.User{Id: uuid.New(), Name: "Ann", CreatedAt: created, UpdatedAt: created}
+		bob := entity.User{Id: uuid.New(), Name: "Bob", CreatedAt: created, UpdatedAt: created}
+		chris := entity.User{Id: uuid.New(), Name: "Chris", CreatedAt: created, UpdatedAt: created}
+		for _, user := range []entity.User{ann, bob, chris} {
+			assert.NoError(t, repo.CreateUser(context.Background(), &user))
+		}
+		return &spyRepo{IUserRepository: repo}, ann, bob, chris
+	}
+
+	t.Run("should only write the users that differ", func(t *testing.T) {
+		// Arrange
+		repo, ann, bob, chris := seed(t)
+		dave := entity.User{Id: uuid.New(), Name: "Dave"}
+		renamedBob := entity.User{Id: bob.Id, Name: "Robert"}
+
+		userService := service.New(repo, service.WithClock(stubClock{now}))
+
+		// Act
+		err := userService.SyncUsers(context.Background(), []entity.User{ann, renamedBob, dave})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{dave.Id}, repo.created)
+		assert.Equal(t, []uuid.UUID{bob.Id}, repo.updated)
+		assert.Equal(t, []uuid.UUID{chris.Id}, repo.deleted)
+
+		users, err := repo.ListUsers(context.Background())
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []entity.User{
+			ann,
+			{Id: bob.Id, Name: "Robert", CreatedAt: created, UpdatedAt: now},
+			{Id: dave.Id, Name: "Dave", CreatedAt: now, UpdatedAt: now},
+		}, users)
+	})
+
+	t.Run("should write nothing when already in sync", func(t *testing.T) {
+		// Arrange
+		repo, ann, bob, chris := seed(t)
+		userService := service.New(repo)
+
+		// Act
+		err := userService.SyncUsers(context.Background(), []entity.User{chris, ann, bob})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Empty(t, repo.created)
+		assert.Empty(t, repo.updated)
+		assert.Empty(t, repo.deleted)
+	})
+
+	t.Run("should roll back every write when one fails", func(t *testing.T) {
+		// Arrange
+		repo, ann, bob, _ := seed(t)
+		before, _ := repo.ListUsers(context.Background())
+		expectedErr := errors.New("delete error")
+		repo.deleteErr = expectedErr
+
+		userService := service.New(repo)
+
+		// Act
+		err := userService.SyncUsers(context.Background(), []entity.User{ann, {Id: bob.Id, Name: "Robert"}})
+
+		// Assert
+		assert.ErrorIs(t, err, expectedErr)
+		after, _ := repo.ListUsers(context.Background())
+		assert.ElementsMatch(t, before, after)
+	})
+}
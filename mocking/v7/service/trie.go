@@ -0,0 +1,103 @@
+package service
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Trie indexes ids by word for prefix search, e.g. autocompleting user names.
+// It is not safe for concurrent use.
+type Trie struct {
+	root      *trieNode
+	normalize func(string) string
+}
+
+type trieNode struct {
+	children map[rune]*trieNode
+	ids      []uuid.UUID
+}
+
+// TrieOption configures optional Trie behaviour.
+type TrieOption func(*Trie)
+
+// WithNormalizer replaces how words and prefixes are normalized before
+// they're compared. The default, strings.ToLower, makes search
+// case-insensitive.
+func WithNormalizer(normalize func(string) string) TrieOption {
+	return func(t *Trie) {
+		t.normalize = normalize
+	}
+}
+
+func NewTrie(opts ...TrieOption) *Trie {
+	t := &Trie{
+		root:      newTrieNode(),
+		normalize: strings.ToLower,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+func newTrieNode() *trieNode {
+	return &trieNode{children: make(map[rune]*trieNode)}
+}
+
+// Insert indexes id under word. Inserting the same word and id twice is a
+// no-op.
+func (t *Trie) Insert(word string, id uuid.UUID) {
+	node := t.root
+	for _, r := range t.normalize(word) {
+		child, ok := node.children[r]
+		if !ok {
+			child = newTrieNode()
+			node.children[r] = child
+		}
+		node = child
+	}
+	if !slices.Contains(node.ids, id) {
+		node.ids = append(node.ids, id)
+	}
+}
+
+// PrefixSearch returns the ids of every word starting with prefix, with
+// shorter words first and words of the same length in rune order. An id
+// inserted under several matching words is returned once.
+func (t *Trie) PrefixSearch(prefix string) []uuid.UUID {
+	node := t.root
+	for _, r := range t.normalize(prefix) {
+		child, ok := node.children[r]
+		if !ok {
+			return nil
+		}
+		node = child
+	}
+
+	var ids []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	level := []*trieNode{node}
+	for len(level) > 0 {
+		var next []*trieNode
+		for _, n := range level {
+			for _, id := range n.ids {
+				if !seen[id] {
+					seen[id] = true
+					ids = append(ids, id)
+				}
+			}
+			runes := make([]rune, 0, len(n.children))
+			for r := range n.children {
+				runes = append(runes, r)
+			}
+			slices.Sort(runes)
+			for _, r := range runes {
+				next = append(next, n.children[r])
+			}
+		}
+		level = next
+	}
+	return ids
+}
@@ -0,0 +1,69 @@
+package service_test
+
+import (
+	"demo/service"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrie(t *testing.T) {
+	ann, anna, andrew, bob := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	newTrie := func(opts ...service.TrieOption) *service.Trie {
+		trie := service.NewTrie(opts...)
+		trie.Insert("Ann", ann)
+		trie.Insert("Anna", anna)
+		trie.Insert("Andrew", andrew)
+		trie.Insert("Bob", bob)
+		return trie
+	}
+
+	t.Run("should return every id whose word starts with the prefix", func(t *testing.T) {
+		// Arrange
+		trie := newTrie()
+
+		// Act
+		got := trie.PrefixSearch("an")
+
+		// Assert
+		assert.Equal(t, []uuid.UUID{ann, anna, andrew}, got)
+	})
+
+	t.Run("should match a whole word", func(t *testing.T) {
+		// Arrange
+		trie := newTrie()
+
+		// Act
+		got := trie.PrefixSearch("BOB")
+
+		// Assert
+		assert.Equal(t, []uuid.UUID{bob}, got)
+	})
+
+	t.Run("should return nothing when no word matches", func(t *testing.T) {
+		// Arrange
+		trie := newTrie()
+
+		// Act
+		got := trie.PrefixSearch("carl")
+
+		// Assert
+		assert.Empty(t, got)
+	})
+
+	t.Run("should use the configured normalizer", func(t *testing.T) {
+		// Arrange
+		caseSensitive := func(s string) string { return s }
+		trie := newTrie(service.WithNormalizer(caseSensitive))
+
+		// Act
+		lower := trie.PrefixSearch("an")
+		upper := trie.PrefixSearch("An")
+
+		// Assert
+		assert.Empty(t, lower)
+		assert.Equal(t, []uuid.UUID{ann, anna, andrew}, upper)
+	})
+}
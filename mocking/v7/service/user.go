@@ -4,23 +4,115 @@ import (
 	"context"
 	"demo/entity"
 	"demo/repository"
+
+	"github.com/google/uuid"
 )
 
 type UserService struct {
-	repo repository.IUserRepository
+	repo  repository.IUserRepository
+	clock Clock
+	cache Cache
+}
+
+// Option configures optional UserService dependencies.
+type Option func(*UserService)
+
+// WithClock replaces the wall clock used for CreatedAt/UpdatedAt.
+func WithClock(clock Clock) Option {
+	return func(u *UserService) {
+		u.clock = clock
+	}
 }
 
-func New(repo repository.IUserRepository) *UserService {
-	return &UserService{
-		repo: repo,
+// WithCache puts cache in front of the repository for GetUserResilient.
+func WithCache(cache Cache) Option {
+	return func(u *UserService) {
+		u.cache = cache
 	}
 }
 
+func New(repo repository.IUserRepository, opts ...Option) *UserService {
+	u := &UserService{
+		repo:  repo,
+		clock: realClock{},
+		cache: nopCache{},
+	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
+}
+
+// CreateUser stamps user.CreatedAt and user.UpdatedAt and saves it.
+func (u *UserService) CreateUser(ctx context.Context, user *entity.User) error {
+	if err := user.Validate(); err != nil {
+		return err
+	}
+
+	now := u.clock.Now()
+	user.CreatedAt = now
+	user.UpdatedAt = now
+	return u.repo.CreateUser(ctx, user)
+}
+
 func (u *UserService) GetUser(ctx context.Context, user *entity.User) error {
 	return u.repo.GetUser(ctx, user)
 }
 
+// GetUsersByIDs fetches the users one by one. Users that were fetched are
+// returned even if others fail, and every failure is reported in errs under
+// its id. Once ctx is done the remaining ids are not fetched and get ctx's error.
+func (u *UserService) GetUsersByIDs(ctx context.Context, ids []uuid.UUID) (users []entity.User, errs map[uuid.UUID]error) {
+	errs = make(map[uuid.UUID]error)
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			errs[id] = err
+			continue
+		}
+
+		user := entity.User{Id: id}
+		if err := u.repo.GetUser(ctx, &user); err != nil {
+			errs[id] = err
+			continue
+		}
+		users = append(users, user)
+	}
+	return users, errs
+}
+
+// GetUserResilient reads the user from the cache when it's fresh, otherwise
+// from the repository, refreshing the cache. If the repository fails and the
+// cache still holds a stale copy, that copy is returned with stale set to true.
+func (u *UserService) GetUserResilient(ctx context.Context, id uuid.UUID) (user entity.User, stale bool, err error) {
+	cached, fresh, ok := u.cache.Get(ctx, id)
+	if ok && fresh {
+		return cached, false, nil
+	}
+
+	user = entity.User{Id: id}
+	if err := u.repo.GetUser(ctx, &user); err != nil {
+		if ok {
+			return cached, true, nil
+		}
+		return entity.User{}, false, err
+	}
+
+	u.cache.Set(ctx, user)
+	return user, false, nil
+}
+
+// UpdateUsers stamps UpdatedAt on each user in place, like an ORM would,
+// and saves them all in one transaction.
 func (u *UserService) UpdateUsers(ctx context.Context, users []entity.User) error {
+	if err := validateUsers(users); err != nil {
+		return err
+	}
+
+	now := u.clock.Now()
+	for i := range users {
+		users[i].UpdatedAt = now
+	}
+
 	return u.repo.Transaction(ctx, func(ctx context.Context) error {
 		if err := u.repo.UpdateUsers(ctx, users); err != nil {
 			return err
@@ -28,3 +120,87 @@ func (u *UserService) UpdateUsers(ctx context.Context, users []entity.User) erro
 		return nil
 	})
 }
+
+// SyncUsers makes the repository hold exactly desired, in one transaction.
+// Users are matched by Id: new ones are created, missing ones deleted, and
+// only those whose Name differs are updated, so unchanged rows aren't written.
+func (u *UserService) SyncUsers(ctx context.Context, desired []entity.User) error {
+	if err := validateUsers(desired); err != nil {
+		return err
+	}
+
+	return u.repo.Transaction(ctx, func(ctx context.Context) error {
+		current, err := u.repo.ListUsers(ctx)
+		if err != nil {
+			return err
+		}
+
+		added, removed, changed := diffUsers(current, desired)
+		now := u.clock.Now()
+
+		for i := range added {
+			added[i].CreatedAt = now
+			added[i].UpdatedAt = now
+			if err := u.repo.CreateUser(ctx, &added[i]); err != nil {
+				return err
+			}
+		}
+		if len(changed) > 0 {
+			for i := range changed {
+				changed[i].UpdatedAt = now
+			}
+			if err := u.repo.UpdateUsers(ctx, changed); err != nil {
+				return err
+			}
+		}
+		for _, user := range removed {
+			if err := u.repo.DeleteUser(ctx, user.Id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// diffUsers works out what SyncUsers has to write, like the Diff helper in
+// generics/v3, which this module can't import. Changed users keep the
+// CreatedAt of their current version.
+func diffUsers(current, desired []entity.User) (added, removed, changed []entity.User) {
+	byID := make(map[uuid.UUID]entity.User, len(current))
+	for _, user := range current {
+		byID[user.Id] = user
+	}
+
+	for _, want := range desired {
+		have, ok := byID[want.Id]
+		switch {
+		case !ok:
+			added = append(added, want)
+		case have.Name != want.Name:
+			want.CreatedAt = have.CreatedAt
+			changed = append(changed, want)
+		}
+		delete(byID, want.Id)
+	}
+
+	for _, user := range current {
+		if _, ok := byID[user.Id]; ok {
+			removed = append(removed, user)
+		}
+	}
+	return added, removed, changed
+}
+
+// validateUsers returns ValidationErrors listing every invalid user, or nil.
+func validateUsers(users []entity.User) error {
+	var errs ValidationErrors
+	for i, user := range users {
+		if err := user.Validate(); err != nil {
+			errs = append(errs, &InvalidUserError{Index: i, Err: err})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -7,6 +7,8 @@ import (
 	"demo/service"
 	"errors"
 	"testing"
+	"testing/synctest"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -169,3 +171,191 @@ func TestUpdateUsers(t *testing.T) {
 		assert.Equal(t, expectedErr, err)
 	})
 }
+
+func TestUpdateUsersValidation(t *testing.T) {
+	t.Run("should report every invalid user without calling the repository", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repository.NewMockIUserRepository(ctrl)
+		users := []entity.User{
+			{Id: uuid.Nil, Name: "No Id"},
+			{Id: uuid.New(), Name: "Valid User"},
+			{Id: uuid.New(), Name: ""},
+		}
+
+		// nothing may reach the repository when validation fails
+		mockRepo.EXPECT().Transaction(gomock.Any(), gomock.Any()).Times(0)
+		mockRepo.EXPECT().UpdateUsers(gomock.Any(), gomock.Any()).Times(0)
+
+		userService := service.New(mockRepo)
+
+		// Act
+		err := userService.UpdateUsers(context.Background(), users)
+
+		// Assert
+		var validationErrs service.ValidationErrors
+		assert.ErrorAs(t, err, &validationErrs)
+		assert.Len(t, validationErrs, 2)
+
+		var first, second *service.InvalidUserError
+		assert.ErrorAs(t, validationErrs[0], &first)
+		assert.Equal(t, 0, first.Index)
+		assert.ErrorIs(t, first, entity.ErrMissingID)
+
+		assert.ErrorAs(t, validationErrs[1], &second)
+		assert.Equal(t, 2, second.Index)
+		assert.ErrorIs(t, second, entity.ErrMissingName)
+
+		// both causes are reachable from the aggregated error too
+		assert.ErrorIs(t, err, entity.ErrMissingID)
+		assert.ErrorIs(t, err, entity.ErrMissingName)
+	})
+}
+
+// stubClock always returns the same time.
+type stubClock struct {
+	now time.Time
+}
+
+func (c stubClock) Now() time.Time {
+	return c.now
+}
+
+func TestUserTimestamps(t *testing.T) {
+	fixedNow := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should stamp CreatedAt and UpdatedAt on create", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repository.NewMockIUserRepository(ctrl)
+		user := &entity.User{Id: uuid.New(), Name: "John Doe"}
+
+		var written entity.User
+		mockRepo.EXPECT().
+			CreateUser(gomock.Any(), user).
+			DoAndReturn(func(_ context.Context, u *entity.User) error {
+				written = *u
+				return nil
+			})
+
+		userService := service.New(mockRepo, service.WithClock(stubClock{now: fixedNow}))
+
+		// Act
+		err := userService.CreateUser(context.Background(), user)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, fixedNow, written.CreatedAt)
+		assert.Equal(t, fixedNow, written.UpdatedAt)
+	})
+
+	t.Run("should stamp UpdatedAt on update", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repository.NewMockIUserRepository(ctrl)
+		createdAt := fixedNow.Add(-24 * time.Hour)
+		users := []entity.User{
+			{Id: uuid.New(), Name: "User 1", CreatedAt: createdAt},
+			{Id: uuid.New(), Name: "User 2", CreatedAt: createdAt},
+		}
+
+		mockRepo.EXPECT().
+			Transaction(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, txFn func(context.Context) error) error {
+				return txFn(context.Background())
+			})
+
+		var written []entity.User
+		mockRepo.EXPECT().
+			UpdateUsers(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, us []entity.User) error {
+				written = us
+				return nil
+			})
+
+		userService := service.New(mockRepo, service.WithClock(stubClock{now: fixedNow}))
+
+		// Act
+		err := userService.UpdateUsers(context.Background(), users)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, written, 2)
+		for _, u := range written {
+			assert.Equal(t, fixedNow, u.UpdatedAt)
+			assert.Equal(t, createdAt, u.CreatedAt, "CreatedAt must not change on update")
+		}
+	})
+}
+
+func TestGetUsersByIDs(t *testing.T) {
+	t.Run("should return partial results when the context is cancelled mid-batch", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := repository.NewMockIUserRepository(ctrl)
+			ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+
+			// every fetch takes a second unless the context is done first
+			mockRepo.EXPECT().
+				GetUser(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, user *entity.User) error {
+					select {
+					case <-time.After(time.Second):
+						user.Name = "User"
+						return nil
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				}).
+				Times(3)
+
+			userService := service.New(mockRepo)
+			ctx, cancel := context.WithTimeout(context.Background(), 2500*time.Millisecond)
+			defer cancel()
+
+			// Act
+			users, errs := userService.GetUsersByIDs(ctx, ids)
+
+			// Assert
+			assert.Len(t, users, 2)
+			assert.Equal(t, ids[0], users[0].Id)
+			assert.Equal(t, ids[1], users[1].Id)
+			assert.Len(t, errs, 1)
+			assert.ErrorIs(t, errs[ids[2]], context.DeadlineExceeded)
+		})
+	})
+
+	t.Run("should carry on past a failed fetch", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repository.NewMockIUserRepository(ctrl)
+		ids := []uuid.UUID{uuid.New(), uuid.New()}
+		expectedErr := errors.New("database error")
+
+		gomock.InOrder(
+			mockRepo.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(expectedErr),
+			mockRepo.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(nil),
+		)
+
+		userService := service.New(mockRepo)
+
+		// Act
+		users, errs := userService.GetUsersByIDs(context.Background(), ids)
+
+		// Assert
+		assert.Len(t, users, 1)
+		assert.Equal(t, ids[1], users[0].Id)
+		assert.ErrorIs(t, errs[ids[0]], expectedErr)
+	})
+}
@@ -7,6 +7,8 @@ import (
 	"sync/atomic"
 	"testing"
 	"time"
+
+	v8 "github.com/quii/learn-go-with-tests/sync/v8"
 )
 
 func TestTokenMonitor(t *testing.T) {
@@ -211,7 +213,7 @@ func TestTokenMonitor_ConcurrencySafety(t *testing.T) {
 		tm = NewTokenMonitor(notificationChan)
 		tm.SetInterval(100 * time.Millisecond)
 
-		var checkStarted atomic.Bool
+		checkStarted := newOneShot()
 		var intervalChanged atomic.Bool
 		var checkAfterChange atomic.Bool
 
@@ -221,7 +223,7 @@ func TestTokenMonitor_ConcurrencySafety(t *testing.T) {
 				return
 			}
 
-			checkStarted.Store(true)
+			checkStarted.Set()
 			// 長時間運行的檢查函數
 			time.Sleep(150 * time.Millisecond)
 		})
@@ -229,8 +231,8 @@ func TestTokenMonitor_ConcurrencySafety(t *testing.T) {
 		go tm.Run()
 
 		// 等待檢查函數開始執行
-		for !checkStarted.Load() {
-			time.Sleep(10 * time.Millisecond)
+		if _, ok := v8.RecvOrTimeout(checkStarted.Done(), 5*time.Second); !ok {
+			t.Fatal("檢查函數未開始執行")
 		}
 
 		// 在檢查函數執行過程中修改間隔時間
@@ -253,11 +255,11 @@ func TestTokenMonitor_ConcurrencySafety(t *testing.T) {
 		notificationChan = make(chan string, 5)
 		tm = NewTokenMonitor(notificationChan)
 
-		var checkStarted atomic.Bool
+		checkStarted := newOneShot()
 		var checkCompleted atomic.Bool
 
 		tm.SetCheckFunc(func(ctx context.Context) {
-			checkStarted.Store(true)
+			checkStarted.Set()
 
 			// 檢查是否在函數執行過程中context被取消
 			select {
@@ -272,8 +274,8 @@ func TestTokenMonitor_ConcurrencySafety(t *testing.T) {
 		go tm.Run()
 
 		// 等待檢查函數開始執行
-		for !checkStarted.Load() {
-			time.Sleep(10 * time.Millisecond)
+		if _, ok := v8.RecvOrTimeout(checkStarted.Done(), 5*time.Second); !ok {
+			t.Fatal("檢查函數未開始執行")
 		}
 
 		// 在檢查函數執行過程中停止服務
@@ -288,3 +290,24 @@ func TestTokenMonitor_ConcurrencySafety(t *testing.T) {
 		}
 	})
 }
+
+// oneShot : 第一次 Set 時送出一個信號，取代 atomic.Bool 加 sleep 輪詢的寫法
+// 信號是送出的值而不是關閉 channel，RecvOrTimeout 才能分辨收到信號與逾時
+type oneShot struct {
+	once sync.Once
+	ch   chan struct{}
+}
+
+func newOneShot() *oneShot {
+	return &oneShot{ch: make(chan struct{}, 1)}
+}
+
+// Set : 發出信號，只有第一次呼叫有作用
+func (o *oneShot) Set() {
+	o.once.Do(func() { o.ch <- struct{}{} })
+}
+
+// Done : 在 Set 之後收到信號的 channel
+func (o *oneShot) Done() <-chan struct{} {
+	return o.ch
+}
@@ -8,6 +8,8 @@ import (
 	"testing"
 	"testing/synctest"
 	"time"
+
+	v8 "github.com/quii/learn-go-with-tests/sync/v8"
 )
 
 func TestTokenMonitor_v2(t *testing.T) {
@@ -48,13 +50,8 @@ func TestTokenMonitor_v2(t *testing.T) {
 		// 發送測試通知
 		notificationChan <- "test message"
 
-		// 等待檢查函數被調用或逾時
-		select {
-		case <-checkDone:
-			// 檢查函數已被調用
-		case <-time.After(200 * time.Millisecond):
-			// 如果沒有被調用，synctest.Wait() 後會失敗
-		}
+		// 等待檢查函數被調用或逾時，如果沒有被調用，synctest.Wait() 後會失敗
+		v8.RecvOrTimeout(checkDone, 200*time.Millisecond)
 
 		// 等待通知被處理和所有goroutine完成
 		synctest.Wait()
@@ -81,13 +78,12 @@ func TestTokenMonitor_NotificationProcessing_v2(t *testing.T) {
 		// 記錄處理的通知
 		var processedNotifications []string
 		var mu sync.Mutex
-		processedCount := atomic.Int32{}
 
 		// 處理完成的通知計數器
 		expectedNotifications := 3
 
-		// 通知處理完成的通道
-		allProcessed := make(chan struct{})
+		// 所有通知處理完成時開啟的 latch
+		allProcessed := newCountdown(expectedNotifications)
 
 		tm.ProcessNotification = func(msg string) {
 			mu.Lock()
@@ -101,10 +97,7 @@ func TestTokenMonitor_NotificationProcessing_v2(t *testing.T) {
 			}
 			time.Sleep(sleepTime)
 
-			// 如果處理完了所有通知，發送信號
-			if processedCount.Add(1) == int32(expectedNotifications) {
-				close(allProcessed)
-			}
+			allProcessed.Done()
 		}
 
 		// Act
@@ -117,12 +110,13 @@ func TestTokenMonitor_NotificationProcessing_v2(t *testing.T) {
 			notificationChan <- msg
 		}
 
-		// 等待所有通知被處理或逾時
-		select {
-		case <-allProcessed:
-			// 所有通知已處理
-		case <-time.After(500 * time.Millisecond):
-			// 逾時，可能有通知未處理
+		// 等待所有通知被處理或逾時，逾時的話下面的斷言會失敗
+		ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+		defer cancel()
+		// synctest.Run 的函式不是在測試的 goroutine 上執行，不能用 t.Fatalf，所以回報錯誤後直接返回
+		if err := allProcessed.Wait(ctx); err != nil {
+			t.Errorf("等待通知處理完成失敗：%v，還剩 %d 個未處理", err, allProcessed.Remaining())
+			return
 		}
 
 		// 等待所有goroutine完成
@@ -168,16 +162,14 @@ func TestTokenMonitor_ConcurrencySafety_v2(t *testing.T) {
 			expectedTotal := numGoroutines * notificationsPerGoroutine
 
 			// 用於等待所有通知被處理
-			allProcessed := make(chan struct{})
+			allProcessed := newCountdown(expectedTotal)
 
 			tm.ProcessNotification = func(msg string) {
 				// 模擬處理時間
 				time.Sleep(1 * time.Millisecond)
 
-				count := processedCount.Add(1)
-				if int(count) == expectedTotal {
-					close(allProcessed)
-				}
+				processedCount.Add(1)
+				allProcessed.Done()
 			}
 
 			go tm.Run()
@@ -201,12 +193,12 @@ func TestTokenMonitor_ConcurrencySafety_v2(t *testing.T) {
 			// 等待所有goroutine完成發送
 			wg.Wait()
 
-			// 等待所有通知被處理或逾時
-			select {
-			case <-allProcessed:
-				// 所有通知已處理
-			case <-time.After(1 * time.Second):
-				// 逾時，可能有通知未處理
+			// 等待所有通知被處理或逾時，逾時的話下面的斷言會失敗
+			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+			defer cancel()
+			if err := allProcessed.Wait(ctx); err != nil {
+				t.Errorf("等待通知處理完成失敗：%v，還剩 %d 個未處理", err, allProcessed.Remaining())
+				return
 			}
 
 			// 等待所有goroutine完成
@@ -260,12 +252,7 @@ func TestTokenMonitor_ConcurrencySafety_v2(t *testing.T) {
 			defer tm.Stop()
 
 			// 等待至少執行完成2次檢查函數或逾時
-			select {
-			case <-checksDone:
-				// 成功執行了多次
-			case <-time.After(500 * time.Millisecond):
-				// 逾時
-			}
+			v8.RecvOrTimeout(checksDone, 500*time.Millisecond)
 
 			// 確保所有goroutine完成
 			synctest.Wait()
@@ -290,8 +277,8 @@ func TestTokenMonitor_ConcurrencySafety_v2(t *testing.T) {
 
 			// 檢查開始執行的信號
 			checkStartedCh := make(chan struct{})
-			// 檢查在間隔修改後執行的信號
-			checkAfterChangeCh := make(chan struct{})
+			// 檢查在間隔修改後執行的信號，用送值而不是關閉 channel，之後的檢查才不會重複關閉
+			checkAfterChangeCh := make(chan struct{}, 1)
 
 			tm.SetCheckFunc(func(ctx context.Context) {
 				if !checkStarted.Swap(true) {
@@ -304,7 +291,10 @@ func TestTokenMonitor_ConcurrencySafety_v2(t *testing.T) {
 
 				if intervalChanged.Load() {
 					checkAfterChange.Store(true)
-					close(checkAfterChangeCh)
+					select {
+					case checkAfterChangeCh <- struct{}{}:
+					default:
+					}
 				}
 			})
 
@@ -319,10 +309,7 @@ func TestTokenMonitor_ConcurrencySafety_v2(t *testing.T) {
 			intervalChanged.Store(true)
 
 			// 等待修改間隔後的檢查函數被調用或逾時
-			select {
-			case <-checkAfterChangeCh:
-				// 成功，有新的檢查函數被調用
-			case <-time.After(300 * time.Millisecond):
+			if _, ok := v8.RecvOrTimeout(checkAfterChangeCh, 300*time.Millisecond); !ok {
 				t.Error("修改間隔後未有新的檢查函數被調用")
 			}
 
@@ -342,8 +329,8 @@ func TestTokenMonitor_ConcurrencySafety_v2(t *testing.T) {
 
 			// 檢查開始執行的信號
 			checkStartedCh := make(chan struct{})
-			// 檢查完成的信號
-			checkCompletedCh := make(chan struct{})
+			// 檢查完成的信號，用送值而不是關閉 channel，RecvOrTimeout 才能分辨完成與逾時
+			checkCompletedCh := make(chan struct{}, 1)
 
 			tm.SetCheckFunc(func(ctx context.Context) {
 				if !checkStarted.Swap(true) {
@@ -353,7 +340,7 @@ func TestTokenMonitor_ConcurrencySafety_v2(t *testing.T) {
 					select {
 					case <-time.After(200 * time.Millisecond):
 						checkCompleted.Store(true)
-						close(checkCompletedCh)
+						checkCompletedCh <- struct{}{}
 					case <-ctx.Done():
 						// context被取消，不標記為完成
 						return
@@ -369,12 +356,9 @@ func TestTokenMonitor_ConcurrencySafety_v2(t *testing.T) {
 			// 在檢查函數執行過程中停止服務
 			tm.Stop()
 
-			// 等待一段時間看是否會完成
-			select {
-			case <-checkCompletedCh:
+			// 等待一段時間看是否會完成，正常情況下檢查函數不會完成
+			if _, ok := v8.RecvOrTimeout(checkCompletedCh, 300*time.Millisecond); ok {
 				t.Error("停止服務後檢查函數仍完成執行")
-			case <-time.After(300 * time.Millisecond):
-				// 正常情況，檢查函數未完成
 			}
 
 			// 確保所有goroutine完成
@@ -388,3 +372,37 @@ func TestTokenMonitor_ConcurrencySafety_v2(t *testing.T) {
 	})
 	// GOEXPERIMENT=synctest go test -race -run TestTokenMonitor_ConcurrencySafety_v2 -v
 }
+
+// countdown : 被呼叫 Done n 次後放行所有等待者，取代「最後一個處理完的人關閉 channel」的寫法
+type countdown struct {
+	remaining atomic.Int32
+	done      chan struct{}
+}
+
+func newCountdown(n int) *countdown {
+	c := &countdown{done: make(chan struct{})}
+	c.remaining.Store(int32(n))
+	return c
+}
+
+// Done : 計數減一，減到零時放行等待者
+func (c *countdown) Done() {
+	if c.remaining.Add(-1) == 0 {
+		close(c.done)
+	}
+}
+
+// Remaining : 還需要幾次 Done
+func (c *countdown) Remaining() int {
+	return int(c.remaining.Load())
+}
+
+// Wait : 等到計數歸零，或 ctx 結束時回傳 ctx.Err()
+func (c *countdown) Wait(ctx context.Context) error {
+	select {
+	case <-c.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
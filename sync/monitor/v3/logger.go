@@ -0,0 +1,15 @@
+package monitor
+
+// Logger : monitor 使用的 log 介面，可替換成任何 logging library 的 adapter
+type Logger interface {
+	Debugf(format string, args ...any)
+	Infof(format string, args ...any)
+	Errorf(format string, args ...any)
+}
+
+// nopLogger : 預設實作，不輸出任何內容
+type nopLogger struct{}
+
+func (nopLogger) Debugf(string, ...any) {}
+func (nopLogger) Infof(string, ...any)  {}
+func (nopLogger) Errorf(string, ...any) {}
@@ -0,0 +1,25 @@
+package monitor
+
+import "time"
+
+// Notification : 結構化的通知內容
+type Notification struct {
+	ID        string
+	Type      string
+	Payload   []byte
+	Timestamp time.Time
+	// Deadline : 處理期限，零值表示沒有期限
+	Deadline time.Time
+}
+
+// PayloadMonitor : 以 Notification 作為通知的 monitor
+type PayloadMonitor = Monitor[Notification]
+
+// NewPayloadMonitor: constructor
+func NewPayloadMonitor(notificationChan <-chan Notification) *PayloadMonitor {
+	tm := NewMonitor(notificationChan)
+	tm.deadlineOf = func(n Notification) time.Time {
+		return n.Deadline
+	}
+	return tm
+}
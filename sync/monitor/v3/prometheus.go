@@ -0,0 +1,56 @@
+//go:build prometheus
+
+package monitor
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// StatsSource : 提供 Stats() 的 monitor，任何 Monitor[T] 都符合
+type StatsSource interface {
+	Stats() Stats
+}
+
+// PrometheusCollector : 將 monitor 的 Stats() 以 counter 的形式提供給 Prometheus
+// 只在以 -tags prometheus 建置時才會編入，避免其他使用者多出 prometheus 的依賴
+type PrometheusCollector struct {
+	source                 StatsSource
+	notificationsProcessed *prometheus.Desc
+	checksRun              *prometheus.Desc
+	checksFailed           *prometheus.Desc
+}
+
+// NewPrometheusCollector : constructor，constLabels 會加在每個 metric 上，可用來區分多個 monitor
+func NewPrometheusCollector(source StatsSource, constLabels prometheus.Labels) *PrometheusCollector {
+	return &PrometheusCollector{
+		source: source,
+		notificationsProcessed: prometheus.NewDesc(
+			"token_monitor_notifications_processed_total",
+			"Number of notifications whose handler has finished.",
+			nil, constLabels,
+		),
+		checksRun: prometheus.NewDesc(
+			"token_monitor_checks_run_total",
+			"Number of checks that have finished.",
+			nil, constLabels,
+		),
+		checksFailed: prometheus.NewDesc(
+			"token_monitor_checks_failed_total",
+			"Number of checks that panicked or timed out.",
+			nil, constLabels,
+		),
+	}
+}
+
+// Describe : 實作 prometheus.Collector
+func (c *PrometheusCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.notificationsProcessed
+	ch <- c.checksRun
+	ch <- c.checksFailed
+}
+
+// Collect : 實作 prometheus.Collector，每次 scrape 時讀取一次 Stats()
+func (c *PrometheusCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.source.Stats()
+	ch <- prometheus.MustNewConstMetric(c.notificationsProcessed, prometheus.CounterValue, float64(stats.NotificationsProcessed))
+	ch <- prometheus.MustNewConstMetric(c.checksRun, prometheus.CounterValue, float64(stats.ChecksRun))
+	ch <- prometheus.MustNewConstMetric(c.checksFailed, prometheus.CounterValue, float64(stats.ChecksFailed))
+}
@@ -0,0 +1,58 @@
+//go:build prometheus
+
+package monitor
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestPrometheusCollector(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		notificationChan := make(chan string)
+		tm := NewTokenMonitor(notificationChan)
+		tm.SetInterval(100 * time.Millisecond)
+		tm.SetMaxChecks(3)
+
+		var checks atomic.Int32
+		tm.SetCheckFunc(func(ctx context.Context) {
+			if checks.Add(1) == 2 {
+				panic("check failed")
+			}
+		})
+
+		go tm.Run()
+		notificationChan <- "token-1"
+		notificationChan <- "token-2"
+		time.Sleep(time.Second)
+		synctest.Wait()
+
+		stats := tm.Stats()
+		want := Stats{NotificationsProcessed: 2, ChecksRun: 3, ChecksFailed: 1}
+		if stats != want {
+			t.Fatalf("統計數字不符，預期 %+v，實際 %+v", want, stats)
+		}
+
+		expected := `
+# HELP token_monitor_checks_failed_total Number of checks that panicked or timed out.
+# TYPE token_monitor_checks_failed_total counter
+token_monitor_checks_failed_total 1
+# HELP token_monitor_checks_run_total Number of checks that have finished.
+# TYPE token_monitor_checks_run_total counter
+token_monitor_checks_run_total 3
+# HELP token_monitor_notifications_processed_total Number of notifications whose handler has finished.
+# TYPE token_monitor_notifications_processed_total counter
+token_monitor_notifications_processed_total 2
+`
+		collector := NewPrometheusCollector(tm, nil)
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected)); err != nil {
+			t.Error(err)
+		}
+	})
+}
@@ -0,0 +1,469 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// ErrMonitorStopped : Stop() 後，檢查函數透過 context.Cause 看到的原因
+	ErrMonitorStopped = errors.New("monitor: stopped")
+	// ErrCheckTimeout : 單次檢查超過 checkTimeout 時的原因
+	ErrCheckTimeout = errors.New("monitor: check timed out")
+	// ErrMaxChecksReached : 執行滿 SetMaxChecks 設定的次數後自行停止的原因
+	ErrMaxChecksReached = errors.New("monitor: max checks reached")
+	// ErrNotificationTimeout : 單則通知處理超過 notificationTimeout 時的原因
+	ErrNotificationTimeout = errors.New("monitor: notification timed out")
+	// ErrStopTimeout : StopWithTimeout 等待逾時，仍有 goroutine 沒有結束
+	ErrStopTimeout = errors.New("monitor: stop timed out")
+)
+
+// Ticker : monitor 需要的 ticker 行為，*time.Ticker 透過 realTicker 實作
+// 測試可以注入手動觸發的 ticker，不必依賴真實或模擬的時間
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+	Reset(d time.Duration)
+}
+
+// tickerFactory : 依 interval 建立 Ticker
+type tickerFactory func(d time.Duration) Ticker
+
+// realTicker : 以 *time.Ticker 實作 Ticker
+type realTicker struct {
+	*time.Ticker
+}
+
+func (t realTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
+
+func newRealTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+// DispatchStrategy : 通知交給 handler 的方式
+type DispatchStrategy int
+
+const (
+	// PerMessageGoroutine : 每則通知各開一個 goroutine 處理，預設值
+	PerMessageGoroutine DispatchStrategy = iota
+	// FixedWorkerPool : 由固定數量的 worker 處理，worker 都在忙時 Run 會等待
+	FixedWorkerPool
+	// Sequential : 由單一 worker 依收到的順序逐一處理
+	Sequential
+)
+
+// defaultWorkers : FixedWorkerPool 未呼叫 SetWorkers 時的 worker 數量
+const defaultWorkers = 4
+
+// Monitor : 簡化版本，T 為通知的型別
+type Monitor[T any] struct {
+	notificationChan    <-chan T
+	notify              chan T // 由 NewMonitorWithBuffer 建立時才有
+	dropped             atomic.Int64
+	expired             atomic.Int64
+	timedOut            atomic.Int64
+	processed           atomic.Int64
+	checksRun           atomic.Int64
+	checksFailed        atomic.Int64
+	deadlineOf          func(T) time.Time // 取得通知的處理期限，nil 表示沒有期限
+	mu                  sync.Mutex        // 保護 ticker、interval、ctx 與 cancel，Run 與 Stop/SetInterval 可能在不同 goroutine
+	ticker              Ticker
+	newTicker           tickerFactory
+	checkFunc           func(context.Context)
+	interval            time.Duration
+	checkTimeout        time.Duration
+	notificationTimeout time.Duration
+	maxChecks           int
+	strategy            DispatchStrategy
+	workers             int
+	middlewares         []func(next func(T)) func(T)
+	handlers            sync.WaitGroup // 追蹤 Run 與處理通知的 goroutine，供 WaitHandlers 等待
+	checks              sync.WaitGroup // 追蹤執行中的檢查函數
+	logger              Logger
+	ctx                 context.Context
+	cancel              context.CancelCauseFunc
+	ProcessNotification func(T)
+	// ProcessNotificationCtx : 有設定時取代 ProcessNotification，並帶入該則通知的 context
+	ProcessNotificationCtx func(context.Context, T)
+}
+
+// TokenMonitor : 以字串作為通知的 monitor，教學範例使用的版本
+type TokenMonitor = Monitor[string]
+
+// NewMonitor: constructor
+func NewMonitor[T any](notificationChan <-chan T) *Monitor[T] {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	return &Monitor[T]{
+		notificationChan: notificationChan,
+		interval:         1 * time.Second,
+		workers:          defaultWorkers,
+		newTicker:        newRealTicker,
+		logger:           nopLogger{},
+		ctx:              ctx,
+		cancel:           cancel,
+		ProcessNotification: func(T) {
+			// 預設實作，不做任何事
+		},
+	}
+}
+
+// NewTokenMonitor: constructor
+func NewTokenMonitor(notificationChan <-chan string) *TokenMonitor {
+	return NewMonitor(notificationChan)
+}
+
+// NewMonitorWithBuffer : constructor，由 monitor 自行持有大小為 size 的通知 channel
+// 呼叫端透過 Notify() 送出通知，buffer 滿時通知會被丟棄並計數
+func NewMonitorWithBuffer[T any](size int) *Monitor[T] {
+	notify := make(chan T, size)
+	tm := NewMonitor(notify)
+	tm.notify = notify
+	return tm
+}
+
+// NewTokenMonitorWithBuffer : NewMonitorWithBuffer 的字串版本
+func NewTokenMonitorWithBuffer(size int) *TokenMonitor {
+	return NewMonitorWithBuffer[string](size)
+}
+
+// Notify : 非阻塞地送出通知，buffer 已滿時丟棄並回傳 false
+// 只適用於 NewMonitorWithBuffer 建立的 monitor，其他情況一律回傳 false
+func (tm *Monitor[T]) Notify(msg T) bool {
+	if tm.notify == nil {
+		// 沒有自己的 buffer，通知送不出去但不算丟棄
+		return false
+	}
+	select {
+	case tm.notify <- msg:
+		return true
+	default:
+		tm.dropped.Add(1)
+		return false
+	}
+}
+
+// DroppedCount : 回傳因 buffer 已滿而被丟棄的通知數量
+func (tm *Monitor[T]) DroppedCount() int64 {
+	return tm.dropped.Load()
+}
+
+// ExpiredCount : 回傳因超過處理期限而未被處理的通知數量
+func (tm *Monitor[T]) ExpiredCount() int64 {
+	return tm.expired.Load()
+}
+
+// Stats : monitor 啟動以來的統計數字
+type Stats struct {
+	NotificationsProcessed int64 // handler 已執行完畢的通知數量，包含 panic 的
+	ChecksRun              int64 // 已執行完畢的檢查次數
+	ChecksFailed           int64 // 其中 panic 或逾時的檢查次數
+}
+
+// Stats : 回傳目前的統計數字，可在 Run 執行中呼叫
+func (tm *Monitor[T]) Stats() Stats {
+	return Stats{
+		NotificationsProcessed: tm.processed.Load(),
+		ChecksRun:              tm.checksRun.Load(),
+		ChecksFailed:           tm.checksFailed.Load(),
+	}
+}
+
+// SetLogger : 設定 logger，預設不輸出任何內容
+func (tm *Monitor[T]) SetLogger(logger Logger) {
+	tm.logger = logger
+}
+
+// SetCheckFunc : set check function
+func (tm *Monitor[T]) SetCheckFunc(fn func(context.Context)) {
+	tm.checkFunc = fn
+}
+
+// SetInterval : set scan interval
+func (tm *Monitor[T]) SetInterval(interval time.Duration) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.interval = interval
+	if tm.ticker != nil {
+		tm.ticker.Reset(interval)
+	}
+}
+
+// SetTickerFactory : 替換建立 ticker 的方式，需在 Run 之前呼叫，預設使用 time.NewTicker
+func (tm *Monitor[T]) SetTickerFactory(factory func(d time.Duration) Ticker) {
+	tm.newTicker = factory
+}
+
+// SetCheckTimeout : 設定單次檢查的逾時時間，0 表示不限制
+// 逾時後檢查函數的 context 會被取消，context.Cause 為 ErrCheckTimeout
+func (tm *Monitor[T]) SetCheckTimeout(timeout time.Duration) {
+	tm.checkTimeout = timeout
+}
+
+// SetNotificationTimeout : 設定單則通知的處理逾時時間，0 表示不限制
+// 逾時後 ProcessNotificationCtx 的 context 會被取消，context.Cause 為 ErrNotificationTimeout
+func (tm *Monitor[T]) SetNotificationTimeout(timeout time.Duration) {
+	tm.notificationTimeout = timeout
+}
+
+// TimedOutCount : 回傳處理逾時的通知數量
+func (tm *Monitor[T]) TimedOutCount() int64 {
+	return tm.timedOut.Load()
+}
+
+// SetMaxChecks : 執行 n 次檢查後自行停止，0 表示不限制
+func (tm *Monitor[T]) SetMaxChecks(n int) {
+	tm.maxChecks = n
+}
+
+// Use : 加入包住通知處理函數的 middleware，可用於 logging、metrics、recover 等共通邏輯
+// 先加入的在最外層，需在 Run 之前呼叫
+func (tm *Monitor[T]) Use(middleware func(next func(T)) func(T)) {
+	tm.middlewares = append(tm.middlewares, middleware)
+}
+
+// SetDispatchStrategy : 設定通知的處理方式，需在 Run 之前呼叫
+func (tm *Monitor[T]) SetDispatchStrategy(strategy DispatchStrategy) {
+	tm.strategy = strategy
+}
+
+// SetWorkers : 設定 FixedWorkerPool 的 worker 數量，需在 Run 之前呼叫
+func (tm *Monitor[T]) SetWorkers(n int) {
+	tm.workers = n
+}
+
+// RunContext : 以 ctx 重新建立 monitor 的 context 後執行 Run，可用於 Stop() 之後重新啟動
+// 統計數字會延續上一次執行的結果，需要歸零時改用 RunContextFresh
+func (tm *Monitor[T]) RunContext(ctx context.Context) {
+	tm.mu.Lock()
+	tm.ctx, tm.cancel = context.WithCancelCause(ctx)
+	tm.mu.Unlock()
+	tm.Run()
+}
+
+// RunContextFresh : 先呼叫 ResetMetrics 將統計數字歸零，再執行 RunContext
+func (tm *Monitor[T]) RunContextFresh(ctx context.Context) {
+	tm.ResetMetrics()
+	tm.RunContext(ctx)
+}
+
+// ResetMetrics : 將 Stats() 與各項計數歸零
+func (tm *Monitor[T]) ResetMetrics() {
+	tm.dropped.Store(0)
+	tm.expired.Store(0)
+	tm.timedOut.Store(0)
+	tm.processed.Store(0)
+	tm.checksRun.Store(0)
+	tm.checksFailed.Store(0)
+}
+
+// Run : 啟動 monitor instance
+func (tm *Monitor[T]) Run() {
+	tm.mu.Lock()
+	tm.ticker = tm.newTicker(tm.interval)
+	ticker, interval := tm.ticker, tm.interval
+	ctx, cancel := tm.ctx, tm.cancel
+	tm.mu.Unlock()
+
+	// Run 執行期間保持計數不為零，派發 handler 時的 Add 才不會與 Wait 同時從零開始
+	tm.handlers.Add(1)
+	defer tm.handlers.Done()
+
+	checks := 0
+	tm.logger.Infof("monitor started, interval=%v", interval)
+
+	jobs := tm.startWorkers(ctx)
+	if jobs != nil {
+		defer close(jobs)
+	}
+
+	for {
+		select {
+		case msg, ok := <-tm.notificationChan:
+			if !ok {
+				return // since channel is closed and then return the process
+			}
+			if jobs == nil {
+				tm.handlers.Add(1)
+				go func() {
+					defer tm.handlers.Done()
+					tm.dispatch(ctx, msg)
+				}()
+				break
+			}
+			select {
+			case jobs <- msg:
+			case <-ctx.Done():
+				return // since context is cancled while waiting for a worker
+			}
+
+		case <-ticker.C():
+			if tm.checkFunc != nil {
+				tm.logger.Debugf("check dispatched")
+				tm.checks.Add(1)
+				go func() {
+					defer tm.checks.Done()
+					tm.runCheck(ctx)
+				}()
+			}
+
+			checks++
+			if tm.maxChecks > 0 && checks >= tm.maxChecks {
+				ticker.Stop()
+				cancel(ErrMaxChecksReached)
+				return // since max checks is reached and then return
+			}
+
+		case <-ctx.Done():
+			return // since context is cancled and then return
+		}
+	}
+}
+
+// startWorkers : 依 strategy 啟動 worker 並回傳派工用的 channel
+// PerMessageGoroutine 不需要 worker，回傳 nil
+func (tm *Monitor[T]) startWorkers(ctx context.Context) chan T {
+	workers := 0
+	switch tm.strategy {
+	case FixedWorkerPool:
+		workers = max(1, tm.workers)
+	case Sequential:
+		workers = 1
+	default:
+		return nil
+	}
+
+	jobs := make(chan T)
+	tm.handlers.Add(workers)
+	for range workers {
+		go func() {
+			defer tm.handlers.Done()
+			for msg := range jobs {
+				tm.dispatch(ctx, msg)
+			}
+		}()
+	}
+	return jobs
+}
+
+// WaitHandlers : 等待所有處理通知的 goroutine 結束，通常在 notificationChan 關閉、Run 返回後呼叫
+// ctx 先結束時回傳 ctx.Err()，handler 則繼續在背景執行
+func (tm *Monitor[T]) WaitHandlers(ctx context.Context) error {
+	return waitCtx(ctx, &tm.handlers)
+}
+
+// waitCtx : 等待所有 wgs 歸零，ctx 先結束時回傳 ctx.Err()
+func waitCtx(ctx context.Context, wgs ...*sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		for _, wg := range wgs {
+			wg.Wait()
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// dispatch : 處理單則通知，已過期的通知直接丟棄，未過期的則在期限到時取消 context
+func (tm *Monitor[T]) dispatch(ctx context.Context, msg T) {
+	defer func() {
+		if r := recover(); r != nil {
+			tm.logger.Errorf("notification handler panicked: %v", r)
+		}
+	}()
+
+	if tm.deadlineOf != nil {
+		if deadline := tm.deadlineOf(msg); !deadline.IsZero() {
+			if !time.Now().Before(deadline) {
+				tm.expired.Add(1)
+				return
+			}
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithDeadline(ctx, deadline)
+			defer cancel()
+		}
+	}
+
+	if tm.notificationTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, tm.notificationTimeout, ErrNotificationTimeout)
+		defer cancel()
+		defer func() {
+			if errors.Is(context.Cause(ctx), ErrNotificationTimeout) {
+				tm.timedOut.Add(1)
+			}
+		}()
+	}
+
+	defer tm.processed.Add(1)
+	handler := tm.ProcessNotification
+	if tm.ProcessNotificationCtx != nil {
+		handler = func(msg T) {
+			tm.ProcessNotificationCtx(ctx, msg)
+		}
+	}
+	for i := len(tm.middlewares) - 1; i >= 0; i-- {
+		handler = tm.middlewares[i](handler)
+	}
+	handler(msg)
+}
+
+// runCheck : 以 monitor 的 context 執行檢查函數，有設定逾時時再包一層
+func (tm *Monitor[T]) runCheck(ctx context.Context) {
+	if tm.checkTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, tm.checkTimeout, ErrCheckTimeout)
+		defer cancel()
+	}
+
+	defer func() {
+		tm.checksRun.Add(1)
+		if r := recover(); r != nil {
+			tm.checksFailed.Add(1)
+			tm.logger.Errorf("check panicked: %v", r)
+			return
+		}
+		if errors.Is(context.Cause(ctx), ErrCheckTimeout) {
+			tm.checksFailed.Add(1)
+			tm.logger.Errorf("check error: %v", ErrCheckTimeout)
+		}
+	}()
+
+	tm.checkFunc(ctx)
+}
+
+// Stop : stop monitor
+func (tm *Monitor[T]) Stop() {
+	tm.mu.Lock()
+	if tm.ticker != nil {
+		tm.ticker.Stop()
+	}
+	cancel := tm.cancel
+	tm.mu.Unlock()
+	cancel(ErrMonitorStopped)
+	tm.logger.Infof("monitor stopped")
+}
+
+// StopWithTimeout : 與 Stop 相同，但會等待 Run、handler 與檢查函數的 goroutine 結束
+// 超過 d 仍未結束時回傳 ErrStopTimeout，避免卡在不理會取消的 handler 上
+func (tm *Monitor[T]) StopWithTimeout(d time.Duration) error {
+	tm.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+	if err := waitCtx(ctx, &tm.handlers, &tm.checks); err != nil {
+		tm.logger.Errorf("stop timed out after %v", d)
+		return ErrStopTimeout
+	}
+	return nil
+}
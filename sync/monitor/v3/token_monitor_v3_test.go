@@ -0,0 +1,733 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+func TestTokenMonitor_CancelCause(t *testing.T) {
+	t.Run("StopCause", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			tm := NewTokenMonitor(make(chan string))
+			tm.SetInterval(100 * time.Millisecond)
+
+			var cause error
+			checkStarted := make(chan struct{})
+			tm.SetCheckFunc(func(ctx context.Context) {
+				select {
+				case checkStarted <- struct{}{}:
+				default:
+				}
+				<-ctx.Done()
+				cause = context.Cause(ctx)
+			})
+
+			go tm.Run()
+
+			// 等待檢查函數開始執行後停止服務
+			<-checkStarted
+			tm.Stop()
+			synctest.Wait()
+
+			if !errors.Is(cause, ErrMonitorStopped) {
+				t.Errorf("取消原因不符，預期 %v，實際 %v", ErrMonitorStopped, cause)
+			}
+		})
+	})
+
+	t.Run("CheckTimeoutCause", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			tm := NewTokenMonitor(make(chan string))
+			tm.SetInterval(100 * time.Millisecond)
+			tm.SetCheckTimeout(30 * time.Millisecond)
+
+			causes := make(chan error, 1)
+			tm.SetCheckFunc(func(ctx context.Context) {
+				<-ctx.Done()
+				select {
+				case causes <- context.Cause(ctx):
+				default:
+				}
+			})
+
+			go tm.Run()
+			defer tm.Stop()
+
+			// 第一次檢查於 100ms 開始，30ms 後逾時
+			start := time.Now()
+			cause := <-causes
+
+			if !errors.Is(cause, ErrCheckTimeout) {
+				t.Errorf("取消原因不符，預期 %v，實際 %v", ErrCheckTimeout, cause)
+			}
+			if elapsed := time.Since(start); elapsed != 130*time.Millisecond {
+				t.Errorf("逾時時間不符，預期 130ms，實際 %v", elapsed)
+			}
+		})
+	})
+}
+
+func TestTokenMonitor_WithBuffer(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		tm := NewTokenMonitorWithBuffer(2)
+
+		var processed atomic.Int32
+		tm.ProcessNotification = func(msg string) {
+			processed.Add(1)
+		}
+
+		// 尚未啟動 Run，buffer 只容得下兩則通知
+		if !tm.Notify("notification1") || !tm.Notify("notification2") {
+			t.Fatal("buffer 未滿時通知不應被丟棄")
+		}
+		if tm.Notify("notification3") {
+			t.Error("buffer 已滿時 Notify 應回傳 false")
+		}
+		if got := tm.DroppedCount(); got != 1 {
+			t.Errorf("丟棄數量不符，預期1，實際%d", got)
+		}
+
+		go tm.Run()
+		defer tm.Stop()
+		synctest.Wait()
+
+		if got := processed.Load(); got != 2 {
+			t.Errorf("通知處理數量不符，預期2，實際%d", got)
+		}
+	})
+}
+
+func TestTokenMonitor_NotifyWithoutBuffer(t *testing.T) {
+	tm := NewTokenMonitor(make(chan string, 1))
+
+	if tm.Notify("notification1") {
+		t.Error("沒有 buffer 的 monitor，Notify 應回傳 false")
+	}
+	if got := tm.DroppedCount(); got != 0 {
+		t.Errorf("沒有 buffer 時不應計入丟棄，實際%d", got)
+	}
+}
+
+func TestTokenMonitor_MaxChecks(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		tm := NewTokenMonitor(make(chan string))
+		tm.SetInterval(100 * time.Millisecond)
+		tm.SetMaxChecks(3)
+
+		var checks atomic.Int32
+		tm.SetCheckFunc(func(ctx context.Context) {
+			checks.Add(1)
+		})
+
+		runExited := make(chan struct{})
+		go func() {
+			tm.Run()
+			close(runExited)
+		}()
+
+		// 推進足夠的時間，若沒有自行停止會執行 10 次
+		time.Sleep(time.Second)
+		synctest.Wait()
+
+		select {
+		case <-runExited:
+		default:
+			t.Fatal("執行滿 3 次後 Run 應自行結束")
+		}
+		if got := checks.Load(); got != 3 {
+			t.Errorf("檢查次數不符，預期3次，實際%d次", got)
+		}
+	})
+}
+
+func TestPayloadMonitor(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		notificationChan := make(chan Notification, 5)
+		tm := NewPayloadMonitor(notificationChan)
+
+		received := make(chan Notification, 1)
+		tm.ProcessNotification = func(n Notification) {
+			received <- n
+		}
+
+		go tm.Run()
+		defer tm.Stop()
+
+		want := Notification{
+			ID:        "n-1",
+			Type:      "token.expired",
+			Payload:   []byte(`{"token":"abc"}`),
+			Timestamp: time.Now(),
+		}
+		notificationChan <- want
+
+		got := <-received
+		if got.ID != want.ID || got.Type != want.Type || !got.Timestamp.Equal(want.Timestamp) {
+			t.Errorf("通知內容不符，預期 %+v，實際 %+v", want, got)
+		}
+		if string(got.Payload) != string(want.Payload) {
+			t.Errorf("Payload 不符，預期 %s，實際 %s", want.Payload, got.Payload)
+		}
+	})
+}
+
+func TestPayloadMonitor_Deadline(t *testing.T) {
+	t.Run("HandlerCancelledAtDeadline", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			notificationChan := make(chan Notification, 5)
+			tm := NewPayloadMonitor(notificationChan)
+
+			type result struct {
+				elapsed time.Duration
+				err     error
+			}
+			results := make(chan result, 1)
+			tm.ProcessNotificationCtx = func(ctx context.Context, n Notification) {
+				start := time.Now()
+				<-ctx.Done()
+				results <- result{elapsed: time.Since(start), err: ctx.Err()}
+			}
+
+			go tm.Run()
+			defer tm.Stop()
+
+			notificationChan <- Notification{ID: "n-1", Deadline: time.Now().Add(50 * time.Millisecond)}
+
+			got := <-results
+			if !errors.Is(got.err, context.DeadlineExceeded) {
+				t.Errorf("context 錯誤不符，預期 %v，實際 %v", context.DeadlineExceeded, got.err)
+			}
+			if got.elapsed != 50*time.Millisecond {
+				t.Errorf("取消時間不符，預期 50ms，實際 %v", got.elapsed)
+			}
+		})
+	})
+
+	t.Run("StaleNotificationDropped", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			notificationChan := make(chan Notification, 5)
+			tm := NewPayloadMonitor(notificationChan)
+
+			var processed atomic.Int32
+			tm.ProcessNotificationCtx = func(ctx context.Context, n Notification) {
+				processed.Add(1)
+			}
+
+			go tm.Run()
+			defer tm.Stop()
+
+			notificationChan <- Notification{ID: "stale", Deadline: time.Now().Add(-time.Second)}
+			notificationChan <- Notification{ID: "fresh", Deadline: time.Now().Add(time.Second)}
+			synctest.Wait()
+
+			if got := processed.Load(); got != 1 {
+				t.Errorf("通知處理次數不符，預期1次，實際%d次", got)
+			}
+			if got := tm.ExpiredCount(); got != 1 {
+				t.Errorf("過期數量不符，預期1，實際%d", got)
+			}
+		})
+	})
+}
+
+// captureLogger : 記錄所有 log 的 Logger，用於測試
+type captureLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *captureLogger) Debugf(format string, args ...any) { l.log("DEBUG", format, args...) }
+func (l *captureLogger) Infof(format string, args ...any)  { l.log("INFO", format, args...) }
+func (l *captureLogger) Errorf(format string, args ...any) { l.log("ERROR", format, args...) }
+
+func (l *captureLogger) log(level, format string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, level+" "+fmt.Sprintf(format, args...))
+}
+
+func (l *captureLogger) Lines() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return slices.Clone(l.lines)
+}
+
+func TestTokenMonitor_Logger(t *testing.T) {
+	t.Run("StartCheckStop", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			logger := &captureLogger{}
+			tm := NewTokenMonitor(make(chan string))
+			tm.SetLogger(logger)
+			tm.SetInterval(100 * time.Millisecond)
+			tm.SetCheckFunc(func(ctx context.Context) {})
+
+			go tm.Run()
+			time.Sleep(150 * time.Millisecond)
+			tm.Stop()
+			synctest.Wait()
+
+			want := []string{
+				"INFO monitor started, interval=100ms",
+				"DEBUG check dispatched",
+				"INFO monitor stopped",
+			}
+			if got := logger.Lines(); !slices.Equal(got, want) {
+				t.Errorf("log 內容不符，預期 %q，實際 %q", want, got)
+			}
+		})
+	})
+
+	t.Run("HandlerPanic", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			logger := &captureLogger{}
+			notificationChan := make(chan string, 1)
+			tm := NewTokenMonitor(notificationChan)
+			tm.SetLogger(logger)
+			tm.ProcessNotification = func(msg string) {
+				panic("boom")
+			}
+
+			go tm.Run()
+			defer tm.Stop()
+
+			notificationChan <- "test message"
+			synctest.Wait()
+
+			if !slices.Contains(logger.Lines(), "ERROR notification handler panicked: boom") {
+				t.Errorf("未記錄 handler panic，實際 %q", logger.Lines())
+			}
+		})
+	})
+}
+
+func TestTokenMonitor_NotificationTimeout(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		notificationChan := make(chan string, 1)
+		tm := NewTokenMonitor(notificationChan)
+		tm.SetNotificationTimeout(200 * time.Millisecond)
+
+		cancelledAfter := make(chan time.Duration, 1)
+		tm.ProcessNotificationCtx = func(ctx context.Context, msg string) {
+			start := time.Now()
+			<-ctx.Done()
+			cancelledAfter <- time.Since(start)
+		}
+
+		go tm.Run()
+		defer tm.Stop()
+
+		notificationChan <- "slow notification"
+
+		if got := <-cancelledAfter; got != 200*time.Millisecond {
+			t.Errorf("取消時間不符，預期 200ms，實際 %v", got)
+		}
+		synctest.Wait()
+		if got := tm.TimedOutCount(); got != 1 {
+			t.Errorf("逾時數量不符，預期1，實際%d", got)
+		}
+	})
+}
+
+func TestTokenMonitor_DispatchStrategy(t *testing.T) {
+	// run : 送出 msgs，每則處理 1 秒，回傳處理順序、同時處理的最大數量與總耗時
+	run := func(strategy DispatchStrategy, workers int, msgs []string) ([]string, int32, time.Duration) {
+		notificationChan := make(chan string)
+		tm := NewTokenMonitor(notificationChan)
+		tm.SetInterval(time.Hour)
+		tm.SetDispatchStrategy(strategy)
+		tm.SetWorkers(workers)
+
+		var (
+			mu          sync.Mutex
+			order       []string
+			inFlight    atomic.Int32
+			maxInFlight atomic.Int32
+		)
+		tm.ProcessNotification = func(msg string) {
+			n := inFlight.Add(1)
+			if n > maxInFlight.Load() {
+				maxInFlight.Store(n)
+			}
+			time.Sleep(time.Second)
+			inFlight.Add(-1)
+
+			mu.Lock()
+			order = append(order, msg)
+			mu.Unlock()
+		}
+
+		go tm.Run()
+		defer tm.Stop()
+
+		start := time.Now()
+		for _, msg := range msgs {
+			notificationChan <- msg
+		}
+		synctest.Wait()
+		for inFlight.Load() > 0 {
+			time.Sleep(time.Second)
+			synctest.Wait()
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		return order, maxInFlight.Load(), time.Since(start)
+	}
+
+	msgs := []string{"token-1", "token-2", "token-3", "token-4"}
+
+	t.Run("PerMessageGoroutine", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			order, maxInFlight, elapsed := run(PerMessageGoroutine, 0, msgs)
+
+			if len(order) != len(msgs) {
+				t.Errorf("處理數量不符，預期 %d，實際 %d", len(msgs), len(order))
+			}
+			if maxInFlight != 4 {
+				t.Errorf("最大同時處理數不符，預期 4，實際 %d", maxInFlight)
+			}
+			if elapsed != time.Second {
+				t.Errorf("總耗時不符，預期 1s，實際 %v", elapsed)
+			}
+		})
+	})
+
+	t.Run("FixedWorkerPool", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			order, maxInFlight, elapsed := run(FixedWorkerPool, 2, msgs)
+
+			if len(order) != len(msgs) {
+				t.Errorf("處理數量不符，預期 %d，實際 %d", len(msgs), len(order))
+			}
+			if maxInFlight != 2 {
+				t.Errorf("最大同時處理數不符，預期 2，實際 %d", maxInFlight)
+			}
+			// 前兩則在 0s 開始處理，後兩則等到 1s 才有空閒的 worker
+			if elapsed != 2*time.Second {
+				t.Errorf("總耗時不符，預期 2s，實際 %v", elapsed)
+			}
+		})
+	})
+
+	t.Run("Sequential", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			order, maxInFlight, elapsed := run(Sequential, 0, msgs)
+
+			if !slices.Equal(order, msgs) {
+				t.Errorf("處理順序不符，預期 %v，實際 %v", msgs, order)
+			}
+			if maxInFlight != 1 {
+				t.Errorf("最大同時處理數不符，預期 1，實際 %d", maxInFlight)
+			}
+			if elapsed != 4*time.Second {
+				t.Errorf("總耗時不符，預期 4s，實際 %v", elapsed)
+			}
+		})
+	})
+}
+
+func TestTokenMonitor_WaitHandlers(t *testing.T) {
+	strategies := map[string]DispatchStrategy{
+		"PerMessageGoroutine": PerMessageGoroutine,
+		"FixedWorkerPool":     FixedWorkerPool,
+		"Sequential":          Sequential,
+	}
+	for name, strategy := range strategies {
+		t.Run(name, func(t *testing.T) {
+			synctest.Test(t, func(t *testing.T) {
+				notificationChan := make(chan string)
+				tm := NewTokenMonitor(notificationChan)
+				tm.SetDispatchStrategy(strategy)
+				defer tm.Stop()
+
+				var processed atomic.Int32
+				tm.ProcessNotification = func(string) {
+					time.Sleep(time.Second)
+					processed.Add(1)
+				}
+
+				runDone := make(chan struct{})
+				go func() {
+					tm.Run()
+					close(runDone)
+				}()
+
+				notificationChan <- "token-1"
+				notificationChan <- "token-2"
+				close(notificationChan) // 處理到一半時關閉 channel
+				<-runDone
+
+				start := time.Now()
+				if err := tm.WaitHandlers(context.Background()); err != nil {
+					t.Fatalf("預期沒有錯誤，實際 %v", err)
+				}
+
+				if got := processed.Load(); got != 2 {
+					t.Errorf("WaitHandlers 返回時處理數量不符，預期 2，實際 %d", got)
+				}
+				if time.Since(start) == 0 {
+					t.Error("WaitHandlers 應等待 handler 完成後才返回")
+				}
+			})
+		})
+	}
+
+	t.Run("ContextDone", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			notificationChan := make(chan string)
+			tm := NewTokenMonitor(notificationChan)
+			defer tm.Stop()
+
+			tm.ProcessNotification = func(string) {
+				time.Sleep(time.Minute)
+			}
+
+			runDone := make(chan struct{})
+			go func() {
+				tm.Run()
+				close(runDone)
+			}()
+
+			notificationChan <- "token-1"
+			close(notificationChan)
+			<-runDone
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			if err := tm.WaitHandlers(ctx); !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("錯誤不符，預期 %v，實際 %v", context.DeadlineExceeded, err)
+			}
+			synctest.Wait()
+			time.Sleep(time.Minute) // 讓 handler 結束，bubble 才能正常收尾
+		})
+	})
+}
+
+// manualTicker : 呼叫 Tick() 才會觸發的 ticker
+type manualTicker struct {
+	c chan time.Time
+}
+
+func newManualTicker() *manualTicker {
+	return &manualTicker{c: make(chan time.Time)}
+}
+
+func (m *manualTicker) C() <-chan time.Time { return m.c }
+func (m *manualTicker) Stop()               {}
+func (m *manualTicker) Reset(time.Duration) {}
+
+// Tick : 觸發一次 tick，會等到 Run 收到為止
+func (m *manualTicker) Tick() {
+	m.c <- time.Now()
+}
+
+func TestTokenMonitor_TickerFactory(t *testing.T) {
+	ticker := newManualTicker()
+	tm := NewTokenMonitor(make(chan string))
+	tm.SetTickerFactory(func(time.Duration) Ticker { return ticker })
+
+	checks := make(chan struct{}, 10)
+	tm.SetCheckFunc(func(ctx context.Context) {
+		checks <- struct{}{}
+	})
+
+	go tm.Run()
+
+	const ticks = 3
+	for range ticks {
+		ticker.Tick()
+	}
+	tm.Stop()
+
+	// 每次 Tick() 都等到 Run 收到才返回，因此恰好派發了 ticks 次檢查
+	for range ticks {
+		<-checks
+	}
+	if extra := len(checks); extra != 0 {
+		t.Errorf("檢查次數不符，預期 %d，實際多了 %d 次", ticks, extra)
+	}
+}
+
+func TestTokenMonitor_Restart(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		tm := NewTokenMonitor(make(chan string))
+		tm.SetInterval(100 * time.Millisecond)
+		tm.SetCheckFunc(func(ctx context.Context) {})
+
+		// runFor : 以 run 啟動 monitor，經過 d 後停止並等待 Run 返回
+		runFor := func(run func(context.Context), d time.Duration) {
+			done := make(chan struct{})
+			go func() {
+				run(context.Background())
+				close(done)
+			}()
+			time.Sleep(d)
+			tm.Stop()
+			<-done
+			synctest.Wait()
+		}
+
+		runFor(tm.RunContext, 350*time.Millisecond)
+		if got := tm.Stats().ChecksRun; got != 3 {
+			t.Errorf("第一次執行的檢查次數不符，預期 3，實際 %d", got)
+		}
+
+		runFor(tm.RunContext, 250*time.Millisecond)
+		if got := tm.Stats().ChecksRun; got != 5 {
+			t.Errorf("重新啟動後統計應延續，預期 5，實際 %d", got)
+		}
+
+		runFor(tm.RunContextFresh, 150*time.Millisecond)
+		if got := tm.Stats().ChecksRun; got != 1 {
+			t.Errorf("RunContextFresh 應從零開始，預期 1，實際 %d", got)
+		}
+	})
+}
+
+func TestTokenMonitor_Use(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		notificationChan := make(chan string)
+		tm := NewTokenMonitor(notificationChan)
+		tm.SetDispatchStrategy(Sequential)
+
+		var (
+			mu    sync.Mutex
+			calls []string
+		)
+		record := func(s string) {
+			mu.Lock()
+			calls = append(calls, s)
+			mu.Unlock()
+		}
+
+		logging := func(next func(string)) func(string) {
+			return func(msg string) {
+				record("log before " + msg)
+				next(msg)
+				record("log after " + msg)
+			}
+		}
+		var count atomic.Int32
+		counting := func(next func(string)) func(string) {
+			return func(msg string) {
+				count.Add(1)
+				record("count " + msg)
+				next(msg)
+			}
+		}
+
+		tm.Use(logging)
+		tm.Use(counting)
+		tm.ProcessNotification = func(msg string) {
+			record("handle " + msg)
+		}
+
+		go tm.Run()
+		defer tm.Stop()
+
+		notificationChan <- "token-1"
+		notificationChan <- "token-2"
+		synctest.Wait()
+
+		want := []string{
+			"log before token-1", "count token-1", "handle token-1", "log after token-1",
+			"log before token-2", "count token-2", "handle token-2", "log after token-2",
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if !slices.Equal(calls, want) {
+			t.Errorf("middleware 執行順序不符\n預期 %v\n實際 %v", want, calls)
+		}
+		if got := count.Load(); got != 2 {
+			t.Errorf("counting middleware 執行次數不符，預期 2，實際 %d", got)
+		}
+	})
+}
+
+func TestTokenMonitor_StopWithTimeout(t *testing.T) {
+	t.Run("StuckHandler", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			notificationChan := make(chan string)
+			tm := NewTokenMonitor(notificationChan)
+
+			release := make(chan struct{})
+			tm.ProcessNotification = func(string) {
+				<-release // 不理會取消的 handler
+			}
+
+			go tm.Run()
+			notificationChan <- "token-1"
+			synctest.Wait()
+
+			start := time.Now()
+			err := tm.StopWithTimeout(time.Second)
+
+			if !errors.Is(err, ErrStopTimeout) {
+				t.Errorf("錯誤不符，預期 %v，實際 %v", ErrStopTimeout, err)
+			}
+			if elapsed := time.Since(start); elapsed != time.Second {
+				t.Errorf("等待時間不符，預期 1s，實際 %v", elapsed)
+			}
+			close(release)
+		})
+	})
+
+	t.Run("HandlerFinishes", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			notificationChan := make(chan string)
+			tm := NewTokenMonitor(notificationChan)
+			tm.ProcessNotificationCtx = func(ctx context.Context, _ string) {
+				<-ctx.Done()
+			}
+
+			go tm.Run()
+			notificationChan <- "token-1"
+			synctest.Wait()
+
+			if err := tm.StopWithTimeout(time.Second); err != nil {
+				t.Errorf("預期沒有錯誤，實際 %v", err)
+			}
+		})
+	})
+}
+
+func TestTokenMonitor_Stats(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		notificationChan := make(chan string)
+		tm := NewTokenMonitor(notificationChan)
+		tm.SetInterval(100 * time.Millisecond)
+		tm.SetCheckTimeout(50 * time.Millisecond)
+		tm.SetMaxChecks(3)
+
+		// 第二次檢查逾時，第三次檢查 panic，兩者都算失敗
+		// 逾時放在第三次以前，因為達到 SetMaxChecks 時最後一次檢查的 ctx 會立刻被取消
+		var checks atomic.Int32
+		tm.SetCheckFunc(func(ctx context.Context) {
+			switch checks.Add(1) {
+			case 2:
+				<-ctx.Done()
+			case 3:
+				panic("check failed")
+			}
+		})
+
+		go tm.Run()
+		notificationChan <- "token-1"
+		notificationChan <- "token-2"
+		time.Sleep(time.Second)
+		synctest.Wait()
+
+		want := Stats{NotificationsProcessed: 2, ChecksRun: 3, ChecksFailed: 2}
+		if got := tm.Stats(); got != want {
+			t.Errorf("統計數字不符，預期 %+v，實際 %+v", want, got)
+		}
+	})
+}
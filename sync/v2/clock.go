@@ -0,0 +1,23 @@
+package v2
+
+import "time"
+
+// Clock is the source of time for code that waits on its own.
+// Tests can swap in a fake, or simply run under synctest with RealClock.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// RealClock is a Clock backed by the time package.
+type RealClock struct{}
+
+// Now returns time.Now().
+func (RealClock) Now() time.Time {
+	return time.Now()
+}
+
+// After returns time.After(d).
+func (RealClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
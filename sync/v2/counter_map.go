@@ -0,0 +1,68 @@
+package v2
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// CounterMap keeps a separate count per key and is safe for concurrent use.
+// Existing keys are incremented atomically under a read lock, so the write
+// lock is only taken the first time a key is seen.
+type CounterMap struct {
+	mu     sync.RWMutex
+	counts map[string]*atomic.Int64
+}
+
+// NewCounterMap returns an empty CounterMap.
+func NewCounterMap() *CounterMap {
+	return &CounterMap{counts: make(map[string]*atomic.Int64)}
+}
+
+// Inc increments the count for key by one.
+func (c *CounterMap) Inc(key string) {
+	c.Add(key, 1)
+}
+
+// Add increments the count for key by n.
+func (c *CounterMap) Add(key string, n int64) {
+	c.counter(key).Add(n)
+}
+
+// Get returns the count for key, or 0 if it has never been incremented.
+func (c *CounterMap) Get(key string) int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if counter, ok := c.counts[key]; ok {
+		return counter.Load()
+	}
+	return 0
+}
+
+// Snapshot returns a copy of every count.
+func (c *CounterMap) Snapshot() map[string]int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	snapshot := make(map[string]int64, len(c.counts))
+	for key, counter := range c.counts {
+		snapshot[key] = counter.Load()
+	}
+	return snapshot
+}
+
+func (c *CounterMap) counter(key string) *atomic.Int64 {
+	c.mu.RLock()
+	counter, ok := c.counts[key]
+	c.mu.RUnlock()
+	if ok {
+		return counter
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if counter, ok := c.counts[key]; ok {
+		return counter
+	}
+	counter = new(atomic.Int64)
+	c.counts[key] = counter
+	return counter
+}
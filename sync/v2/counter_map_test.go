@@ -0,0 +1,54 @@
+package v2
+
+import (
+	"fmt"
+	"maps"
+	"sync"
+	"testing"
+)
+
+func TestCounterMap(t *testing.T) {
+	t.Run("counts each key separately", func(t *testing.T) {
+		counts := NewCounterMap()
+		counts.Inc("checks")
+		counts.Inc("checks")
+		counts.Add("notifications", 5)
+
+		if got := counts.Get("checks"); got != 2 {
+			t.Errorf("got %d checks, want 2", got)
+		}
+		if got := counts.Get("notifications"); got != 5 {
+			t.Errorf("got %d notifications, want 5", got)
+		}
+		if got := counts.Get("unknown"); got != 0 {
+			t.Errorf("got %d for an unknown key, want 0", got)
+		}
+	})
+
+	t.Run("it runs safely concurrently", func(t *testing.T) {
+		const keys = 10
+		const incrementsPerKey = 100
+		counts := NewCounterMap()
+
+		var wg sync.WaitGroup
+		wg.Add(keys * incrementsPerKey)
+		for i := 0; i < keys; i++ {
+			key := fmt.Sprintf("key-%d", i)
+			for j := 0; j < incrementsPerKey; j++ {
+				go func() {
+					defer wg.Done()
+					counts.Inc(key)
+				}()
+			}
+		}
+		wg.Wait()
+
+		want := make(map[string]int64, keys)
+		for i := 0; i < keys; i++ {
+			want[fmt.Sprintf("key-%d", i)] = incrementsPerKey
+		}
+		if got := counts.Snapshot(); !maps.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+}
@@ -0,0 +1,55 @@
+package v2
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// FlushableCounter is a counter that is periodically read and reset, e.g.
+// to push deltas to a metrics backend. It is safe for concurrent use.
+type FlushableCounter struct {
+	value atomic.Int64
+}
+
+// Inc increments the counter by one.
+func (c *FlushableCounter) Inc() {
+	c.value.Add(1)
+}
+
+// Add increments the counter by n.
+func (c *FlushableCounter) Add(n int64) {
+	c.value.Add(n)
+}
+
+// Flush returns the count and resets it to zero in one atomic step, so an
+// increment landing between the read and the reset can't be lost.
+func (c *FlushableCounter) Flush() int64 {
+	return c.value.Swap(0)
+}
+
+// StartAutoFlush flushes the counter to sink every interval in a new
+// goroutine until ctx is cancelled, then flushes whatever was counted since
+// the last tick. The returned channel is closed after that final flush.
+func (c *FlushableCounter) StartAutoFlush(ctx context.Context, interval time.Duration, sink func(int64)) <-chan struct{} {
+	return c.StartAutoFlushWithClock(ctx, RealClock{}, interval, sink)
+}
+
+// StartAutoFlushWithClock is StartAutoFlush with the interval measured by
+// clock.
+func (c *FlushableCounter) StartAutoFlushWithClock(ctx context.Context, clock Clock, interval time.Duration, sink func(int64)) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-ctx.Done():
+				sink(c.Flush())
+				return
+			case <-clock.After(interval):
+				sink(c.Flush())
+			}
+		}
+	}()
+	return done
+}
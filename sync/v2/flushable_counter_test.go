@@ -0,0 +1,90 @@
+package v2
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+func TestFlushableCounter(t *testing.T) {
+	t.Run("Flush returns the count and resets it", func(t *testing.T) {
+		var counter FlushableCounter
+		counter.Inc()
+		counter.Add(4)
+
+		if got := counter.Flush(); got != 5 {
+			t.Errorf("got %d, want 5", got)
+		}
+		if got := counter.Flush(); got != 0 {
+			t.Errorf("got %d after flushing, want 0", got)
+		}
+	})
+
+	t.Run("no increments are lost while flushing concurrently", func(t *testing.T) {
+		const goroutines = 10
+		const incrementsEach = 1000
+		var counter FlushableCounter
+
+		var wg sync.WaitGroup
+		wg.Add(goroutines)
+		for i := 0; i < goroutines; i++ {
+			go func() {
+				defer wg.Done()
+				for j := 0; j < incrementsEach; j++ {
+					counter.Inc()
+				}
+			}()
+		}
+
+		done := make(chan struct{})
+		flushed := make(chan int64)
+		go func() {
+			var total int64
+			for {
+				select {
+				case <-done:
+					flushed <- total
+					return
+				default:
+					total += counter.Flush()
+				}
+			}
+		}()
+
+		wg.Wait()
+		close(done)
+		total := <-flushed + counter.Flush()
+
+		if total != goroutines*incrementsEach {
+			t.Errorf("got %d in total, want %d", total, goroutines*incrementsEach)
+		}
+	})
+}
+
+func TestFlushableCounterAutoFlush(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		var counter FlushableCounter
+		var flushed []int64
+		ctx, cancel := context.WithCancel(context.Background())
+
+		done := counter.StartAutoFlush(ctx, time.Second, func(n int64) {
+			flushed = append(flushed, n)
+		})
+
+		counter.Add(3)
+		time.Sleep(1500 * time.Millisecond) // first flush at 1s
+		counter.Add(2)
+		time.Sleep(time.Second) // second flush at 2s
+		counter.Inc()
+		cancel() // final flush of the partial count
+		<-done
+
+		want := []int64{3, 2, 1}
+		if !slices.Equal(flushed, want) {
+			t.Errorf("got %v, want %v", flushed, want)
+		}
+	})
+}
@@ -60,3 +60,22 @@ func assertCounter(t testing.TB, got ICounter, want int) {
 		t.Errorf("got %d, want %d", got.Value(), want)
 	}
 }
+
+func BenchmarkCounter(b *testing.B) {
+	benchmarkCounter(b, NewCounter())
+}
+
+func BenchmarkAtomicCounter(b *testing.B) {
+	benchmarkCounter(b, &AtomicCounter{})
+}
+
+// benchmarkCounter increments one shared counter from GOMAXPROCS goroutines,
+// so the cost of contention shows up in ns/op.
+// go test -bench=Counter -cpu=1,4,8 ./v2
+func benchmarkCounter(b *testing.B, counter ICounter) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			counter.Inc()
+		}
+	})
+}
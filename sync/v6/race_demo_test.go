@@ -0,0 +1,22 @@
+//go:build racedemo
+
+package v6
+
+import "testing"
+
+// 這個測試預期會失敗，用來示範 race detector 如何抓到 RaceyCounter 的問題。
+// 因此只有在加上 racedemo build tag 時才會編譯，平常的 go test 與 CI 不會執行它。
+func TestRaceyCounter(t *testing.T) {
+	counter := &RaceyCounter{}
+
+	incrementConcurrently(1000, counter.Inc)
+
+	t.Logf("counter = %d, want 1000", counter.Value())
+
+	// go test -race -tags racedemo -run TestRaceyCounter -v
+	// WARNING: DATA RACE
+	// --- FAIL: TestRaceyCounter
+	//     testing.go: race detected during execution of test
+	// race detector 回報 data race 讓測試失敗，即使這次的結果剛好是 1000 也一樣。
+	// 對照 TestSafeCounter，把 RaceyCounter 換成 SafeCounter 警告就會消失。
+}
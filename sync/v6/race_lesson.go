@@ -0,0 +1,38 @@
+package v6
+
+import "sync"
+
+// RaceyCounter 沒有任何同步機制，多個 goroutine 同時呼叫 Inc 會產生 data race
+type RaceyCounter struct {
+	value int
+}
+
+// Inc 增加計數器的值，但 value++ 是「讀取、加一、寫回」三個步驟，並不是原子操作
+func (c *RaceyCounter) Inc() {
+	c.value++
+}
+
+// Value 返回計數器的當前值
+func (c *RaceyCounter) Value() int {
+	return c.value
+}
+
+// SafeCounter 以 mutex 保護 value，是 RaceyCounter 的修正版本
+type SafeCounter struct {
+	mu    sync.Mutex
+	value int
+}
+
+// Inc 增加計數器的值
+func (c *SafeCounter) Inc() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.value++
+}
+
+// Value 返回計數器的當前值
+func (c *SafeCounter) Value() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.value
+}
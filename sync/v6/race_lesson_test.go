@@ -0,0 +1,32 @@
+package v6
+
+import (
+	"sync"
+	"testing"
+)
+
+// incrementConcurrently 讓 n 個 goroutine 同時呼叫 inc
+func incrementConcurrently(n int, inc func()) {
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+			inc()
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSafeCounter(t *testing.T) {
+	counter := &SafeCounter{}
+
+	incrementConcurrently(1000, counter.Inc)
+
+	if got := counter.Value(); got != 1000 {
+		t.Errorf("counter = %d, want 1000", got)
+	}
+
+	// go test -race -run TestSafeCounter -v
+	// 有 mutex 保護，race detector 不會有任何警告，結果也一定是 1000。
+}
@@ -0,0 +1,60 @@
+package v8
+
+import (
+	"testing"
+	"time"
+)
+
+// AssertReceive returns the next value from ch, failing the test if nothing
+// arrives within timeout or ch is closed. It uses time.After, so under
+// synctest the timeout runs on the bubble's fake clock.
+func AssertReceive[T any](t testing.TB, ch <-chan T, timeout time.Duration) T {
+	t.Helper()
+
+	select {
+	case v, ok := <-ch:
+		if !ok {
+			t.Fatalf("channel closed, want a value")
+		}
+		return v
+	case <-time.After(timeout):
+		t.Fatalf("received nothing within %v", timeout)
+	}
+	var zero T
+	return zero
+}
+
+// AssertNoReceive fails the test if ch yields anything, a close included,
+// within window.
+func AssertNoReceive[T any](t testing.TB, ch <-chan T, window time.Duration) {
+	t.Helper()
+
+	select {
+	case v, ok := <-ch:
+		if !ok {
+			t.Errorf("channel closed within %v, want nothing", window)
+			return
+		}
+		t.Errorf("received %v within %v, want nothing", v, window)
+	case <-time.After(window):
+	}
+}
+
+// AssertClosed fails the test unless ch is closed within timeout.
+// Values still buffered in ch are received and discarded on the way.
+func AssertClosed[T any](t testing.TB, ch <-chan T, timeout time.Duration) {
+	t.Helper()
+
+	deadline := time.After(timeout)
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				return
+			}
+		case <-deadline:
+			t.Errorf("channel still open after %v", timeout)
+			return
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package v8
+
+import (
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+func TestAssertReceive(t *testing.T) {
+	t.Run("returns a value that is already buffered", func(t *testing.T) {
+		spy := &spyTB{TB: t}
+		ch := make(chan int, 1)
+		ch <- 42
+
+		got := AssertReceive(spy, ch, time.Second)
+
+		if spy.failed {
+			t.Error("expected no failure to be reported")
+		}
+		if got != 42 {
+			t.Errorf("got %d, want 42", got)
+		}
+	})
+
+	t.Run("returns a value that arrives before the timeout", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			spy := &spyTB{TB: t}
+			ch := make(chan string, 1)
+			go func() {
+				time.Sleep(500 * time.Millisecond)
+				ch <- "token"
+			}()
+
+			got := AssertReceive(spy, ch, time.Second)
+
+			if spy.failed {
+				t.Error("expected no failure to be reported")
+			}
+			if got != "token" {
+				t.Errorf("got %q, want %q", got, "token")
+			}
+		})
+	})
+
+	t.Run("fails when nothing arrives in time", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			spy := &spyTB{TB: t}
+			ch := make(chan int, 1)
+
+			AssertReceive(spy, ch, time.Second)
+
+			if !spy.failed {
+				t.Error("expected a timeout to be reported")
+			}
+		})
+	})
+
+	t.Run("fails when the channel is closed", func(t *testing.T) {
+		spy := &spyTB{TB: t}
+		ch := make(chan int, 1)
+		close(ch)
+
+		AssertReceive(spy, ch, time.Second)
+
+		if !spy.failed {
+			t.Error("expected a closed channel to be reported")
+		}
+	})
+}
+
+func TestAssertNoReceive(t *testing.T) {
+	t.Run("passes when nothing arrives within the window", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			spy := &spyTB{TB: t}
+			ch := make(chan int, 1)
+			go func() {
+				time.Sleep(2 * time.Second)
+				ch <- 1
+			}()
+
+			AssertNoReceive(spy, ch, time.Second)
+
+			if spy.failed {
+				t.Error("expected no failure to be reported")
+			}
+			<-ch // let the late sender finish before the bubble ends
+		})
+	})
+
+	t.Run("fails when a value is buffered", func(t *testing.T) {
+		spy := &spyTB{TB: t}
+		ch := make(chan int, 1)
+		ch <- 1
+
+		AssertNoReceive(spy, ch, time.Second)
+
+		if !spy.failed {
+			t.Error("expected the received value to be reported")
+		}
+	})
+}
+
+func TestAssertClosed(t *testing.T) {
+	t.Run("passes when the channel is closed in time", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			spy := &spyTB{TB: t}
+			allProcessed := make(chan struct{})
+			go func() {
+				time.Sleep(100 * time.Millisecond)
+				close(allProcessed)
+			}()
+
+			AssertClosed(spy, allProcessed, time.Second)
+
+			if spy.failed {
+				t.Error("expected no failure to be reported")
+			}
+		})
+	})
+
+	t.Run("skips values buffered before the close", func(t *testing.T) {
+		spy := &spyTB{TB: t}
+		ch := make(chan int, 2)
+		ch <- 1
+		ch <- 2
+		close(ch)
+
+		AssertClosed(spy, ch, time.Second)
+
+		if spy.failed {
+			t.Error("expected no failure to be reported")
+		}
+	})
+
+	t.Run("fails when the channel stays open", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			spy := &spyTB{TB: t}
+			ch := make(chan struct{})
+
+			AssertClosed(spy, ch, time.Second)
+
+			if !spy.failed {
+				t.Error("expected an open channel to be reported")
+			}
+		})
+	})
+}
@@ -0,0 +1,26 @@
+package v8
+
+import "sync/atomic"
+
+// AtomicValue is a typed atomic.Value. Because it stores a *T, it can't
+// panic on inconsistent types, and func values such as a swappable check
+// function can be stored too. The zero value holds nothing.
+type AtomicValue[T any] struct {
+	p atomic.Pointer[T]
+}
+
+// Load returns the stored value, or T's zero value and false if nothing
+// has been stored yet.
+func (v *AtomicValue[T]) Load() (T, bool) {
+	p := v.p.Load()
+	if p == nil {
+		var zero T
+		return zero, false
+	}
+	return *p, true
+}
+
+// Store replaces the stored value.
+func (v *AtomicValue[T]) Store(value T) {
+	v.p.Store(&value)
+}
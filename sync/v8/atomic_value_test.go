@@ -0,0 +1,48 @@
+package v8
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestAtomicValue(t *testing.T) {
+	t.Run("empty until stored", func(t *testing.T) {
+		var v AtomicValue[string]
+
+		if got, ok := v.Load(); ok || got != "" {
+			t.Errorf("got %q, %v, want empty and false", got, ok)
+		}
+
+		v.Store("hello")
+		if got, ok := v.Load(); !ok || got != "hello" {
+			t.Errorf("got %q, %v, want %q, true", got, ok, "hello")
+		}
+	})
+
+	t.Run("concurrent stores and loads", func(t *testing.T) {
+		var check AtomicValue[func() int]
+		const writers = 10
+
+		var wg sync.WaitGroup
+		wg.Add(writers * 2)
+		for i := range writers {
+			go func() {
+				defer wg.Done()
+				check.Store(func() int { return i })
+			}()
+			go func() {
+				defer wg.Done()
+				if fn, ok := check.Load(); ok {
+					if got := fn(); got < 0 || got >= writers {
+						t.Errorf("loaded a func returning %d, want 0 to %d", got, writers-1)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+
+		if _, ok := check.Load(); !ok {
+			t.Error("want a value after the stores")
+		}
+	})
+}
@@ -0,0 +1,39 @@
+package v8
+
+import "sync"
+
+// Barrier holds goroutines back until n of them have reached it, then lets
+// them all through together. It resets itself, so the same Barrier can
+// separate one phase of work from the next.
+type Barrier struct {
+	n int
+
+	mu      sync.Mutex
+	waiting int
+	release chan struct{} // closed when the current generation is complete
+}
+
+// NewBarrier returns a Barrier for n goroutines. It panics if n < 1.
+func NewBarrier(n int) *Barrier {
+	if n < 1 {
+		panic("v8: Barrier needs at least one goroutine")
+	}
+	return &Barrier{n: n, release: make(chan struct{})}
+}
+
+// Wait blocks until n goroutines, this one included, have called Wait.
+func (b *Barrier) Wait() {
+	b.mu.Lock()
+	b.waiting++
+	if b.waiting == b.n {
+		close(b.release)
+		b.release = make(chan struct{})
+		b.waiting = 0
+		b.mu.Unlock()
+		return
+	}
+	release := b.release
+	b.mu.Unlock()
+
+	<-release
+}
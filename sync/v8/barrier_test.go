@@ -0,0 +1,53 @@
+package v8
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+func TestBarrier(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		const workers = 3
+		barrier := NewBarrier(workers)
+
+		var phase [2]atomic.Int32 // goroutines through the barrier in each cycle
+		var wg sync.WaitGroup
+		wg.Add(workers)
+		for i := range workers {
+			go func() {
+				defer wg.Done()
+				for cycle := range phase {
+					time.Sleep(time.Duration(i+1) * time.Second) // arrive one at a time
+					barrier.Wait()
+					phase[cycle].Add(1)
+				}
+			}()
+		}
+
+		// first cycle: the workers arrive at 1s, 2s and 3s
+		time.Sleep(2500 * time.Millisecond)
+		synctest.Wait()
+		if got := phase[0].Load(); got != 0 {
+			t.Errorf("cycle 1: %d goroutines passed before all arrived", got)
+		}
+		time.Sleep(time.Second)
+		synctest.Wait()
+		if got := phase[0].Load(); got != workers {
+			t.Errorf("cycle 1: got %d goroutines through, want %d", got, workers)
+		}
+
+		// second cycle: released at 3s, they arrive again at 4s, 5s and 6s
+		time.Sleep(2 * time.Second)
+		synctest.Wait()
+		if got := phase[1].Load(); got != 0 {
+			t.Errorf("cycle 2: %d goroutines passed before all arrived", got)
+		}
+		wg.Wait()
+		if got := phase[1].Load(); got != workers {
+			t.Errorf("cycle 2: got %d goroutines through, want %d", got, workers)
+		}
+	})
+}
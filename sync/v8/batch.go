@@ -0,0 +1,63 @@
+package v8
+
+import (
+	"context"
+	"time"
+)
+
+// Batch groups the values of in into slices. A batch is sent as soon as it
+// holds maxSize values, or when maxWait has passed since its first value.
+// When in is closed any partial batch is sent before the output is closed;
+// when ctx is done the output is closed and a partial batch is dropped.
+func Batch[T any](ctx context.Context, in <-chan T, maxSize int, maxWait time.Duration) <-chan []T {
+	return BatchWithClock(ctx, RealClock{}, in, maxSize, maxWait)
+}
+
+// BatchWithClock is Batch with the passing of maxWait measured by clock.
+func BatchWithClock[T any](ctx context.Context, clock Clock, in <-chan T, maxSize int, maxWait time.Duration) <-chan []T {
+	out := make(chan []T)
+
+	go func() {
+		defer close(out)
+
+		var batch []T
+		var timeout <-chan time.Time // nil, and so never ready, while batch is empty
+
+		flush := func() bool {
+			select {
+			case out <- batch:
+				batch, timeout = nil, nil
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for {
+			select {
+			case v, ok := <-in:
+				if !ok {
+					if len(batch) > 0 {
+						flush()
+					}
+					return
+				}
+				if len(batch) == 0 {
+					timeout = clock.After(maxWait)
+				}
+				batch = append(batch, v)
+				if len(batch) >= maxSize && !flush() {
+					return
+				}
+			case <-timeout:
+				if !flush() {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
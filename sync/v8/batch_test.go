@@ -0,0 +1,45 @@
+package v8
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+func TestBatch(t *testing.T) {
+	t.Run("flushes when the batch is full", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			out := Batch(context.Background(), feed(1, 2, 3, 4, 5, 6), 3, time.Minute)
+
+			got := drain(out)
+			want := [][]int{{1, 2, 3}, {4, 5, 6}}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	})
+
+	t.Run("flushes a partial batch after maxWait", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			in := make(chan int)
+			out := Batch(context.Background(), in, 10, time.Second)
+			defer close(in)
+
+			start := time.Now()
+			in <- 1
+			time.Sleep(300 * time.Millisecond)
+			in <- 2
+
+			got := <-out
+			if want := []int{1, 2}; !reflect.DeepEqual(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+			if elapsed := time.Since(start); elapsed != time.Second {
+				t.Errorf("flushed after %v, want 1s", elapsed)
+			}
+		})
+	})
+}
@@ -0,0 +1,96 @@
+package v8
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrQueueClosed is returned when putting to, or taking from an empty,
+// closed BlockingQueue.
+var ErrQueueClosed = errors.New("v8: queue closed")
+
+// BlockingQueue is a bounded FIFO queue whose Put blocks while it is full
+// and whose Take blocks while it is empty.
+type BlockingQueue[T any] struct {
+	items     chan T
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewBlockingQueue returns a queue that holds up to capacity items.
+func NewBlockingQueue[T any](capacity int) *BlockingQueue[T] {
+	return &BlockingQueue[T]{
+		items: make(chan T, capacity),
+		done:  make(chan struct{}),
+	}
+}
+
+// Put adds value to the queue, waiting for room until ctx is done.
+func (q *BlockingQueue[T]) Put(ctx context.Context, value T) error {
+	select {
+	case <-q.done:
+		return ErrQueueClosed
+	default:
+	}
+
+	select {
+	case q.items <- value:
+		return nil
+	case <-q.done:
+		return ErrQueueClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Take removes the oldest item, waiting for one until ctx is done.
+// Items still buffered when the queue is closed can still be taken.
+func (q *BlockingQueue[T]) Take(ctx context.Context) (T, error) {
+	select {
+	case value := <-q.items:
+		return value, nil
+	case <-q.done:
+		select {
+		case value := <-q.items:
+			return value, nil
+		default:
+			var zero T
+			return zero, ErrQueueClosed
+		}
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Close stops the queue accepting new items. It is safe to call more than once.
+func (q *BlockingQueue[T]) Close() {
+	q.closeOnce.Do(func() {
+		close(q.done)
+	})
+}
+
+// DrainWithin takes items until the queue is closed and empty, or until ctx
+// is done, and returns everything it took.
+func (q *BlockingQueue[T]) DrainWithin(ctx context.Context) []T {
+	var drained []T
+	for {
+		select {
+		case value := <-q.items:
+			drained = append(drained, value)
+		case <-q.done:
+			// closed, so nothing new is coming: take what is left without waiting
+			for {
+				select {
+				case value := <-q.items:
+					drained = append(drained, value)
+				default:
+					return drained
+				}
+			}
+		case <-ctx.Done():
+			return drained
+		}
+	}
+}
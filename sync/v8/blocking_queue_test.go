@@ -0,0 +1,102 @@
+package v8
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+func TestBlockingQueue(t *testing.T) {
+	t.Run("items come out in the order they went in", func(t *testing.T) {
+		ctx := context.Background()
+		q := NewBlockingQueue[int](2)
+		q.Put(ctx, 1)
+		q.Put(ctx, 2)
+
+		first, _ := q.Take(ctx)
+		second, _ := q.Take(ctx)
+
+		if first != 1 || second != 2 {
+			t.Errorf("got %d, %d, want 1, 2", first, second)
+		}
+	})
+
+	t.Run("Put on a full queue waits until the context is done", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			q := NewBlockingQueue[int](1)
+			q.Put(context.Background(), 1)
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			if err := q.Put(ctx, 2); !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+			}
+		})
+	})
+
+	t.Run("closed queue rejects Put and Take once empty", func(t *testing.T) {
+		ctx := context.Background()
+		q := NewBlockingQueue[int](1)
+		q.Put(ctx, 1)
+		q.Close()
+
+		if err := q.Put(ctx, 2); !errors.Is(err, ErrQueueClosed) {
+			t.Errorf("got error %v, want %v", err, ErrQueueClosed)
+		}
+		if got, err := q.Take(ctx); err != nil || got != 1 {
+			t.Errorf("got %d, %v, want 1, nil", got, err)
+		}
+		if _, err := q.Take(ctx); !errors.Is(err, ErrQueueClosed) {
+			t.Errorf("got error %v, want %v", err, ErrQueueClosed)
+		}
+	})
+}
+
+func TestBlockingQueue_DrainWithin(t *testing.T) {
+	t.Run("returns everything from a closed queue before the deadline", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			q := NewBlockingQueue[string](3)
+			for _, v := range []string{"a", "b", "c"} {
+				q.Put(context.Background(), v)
+			}
+			q.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			start := time.Now()
+			got := q.DrainWithin(ctx)
+
+			if want := []string{"a", "b", "c"}; !slices.Equal(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+			if elapsed := time.Since(start); elapsed != 0 {
+				t.Errorf("took %v, want no waiting", elapsed)
+			}
+		})
+	})
+
+	t.Run("stops at the deadline if the queue is never closed", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			q := NewBlockingQueue[string](3)
+			q.Put(context.Background(), "a")
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			start := time.Now()
+			got := q.DrainWithin(ctx)
+
+			if want := []string{"a"}; !slices.Equal(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+			if elapsed := time.Since(start); elapsed != time.Second {
+				t.Errorf("took %v, want 1s", elapsed)
+			}
+		})
+	})
+}
@@ -0,0 +1,78 @@
+package v8
+
+import (
+	"context"
+	"sync"
+)
+
+// Broadcaster sends every published value to all of its subscribers.
+type Broadcaster[T any] struct {
+	done      chan struct{} // closed by Close, so blocked publishers give up
+	closeOnce sync.Once
+
+	mu          sync.RWMutex
+	subscribers []chan T
+	closed      bool
+}
+
+// NewBroadcaster returns a Broadcaster with no subscribers.
+func NewBroadcaster[T any]() *Broadcaster[T] {
+	return &Broadcaster[T]{done: make(chan struct{})}
+}
+
+// Subscribe returns a channel receiving every value published after this
+// call. The channel is closed when the Broadcaster is closed.
+func (b *Broadcaster[T]) Subscribe() <-chan T {
+	ch := make(chan T, subscriberBuffer)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		close(ch)
+		return ch
+	}
+	b.subscribers = append(b.subscribers, ch)
+	return ch
+}
+
+// Publish sends v to every subscriber, waiting for any whose buffer is
+// full. It does nothing once the Broadcaster is closed, and a Publish
+// waiting on a slow subscriber gives up when Close is called.
+func (b *Broadcaster[T]) Publish(v T) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		return
+	}
+	for _, ch := range b.subscribers {
+		select {
+		case ch <- v:
+		case <-b.done:
+			return
+		}
+	}
+}
+
+// Run blocks until ctx is done and then closes the Broadcaster.
+func (b *Broadcaster[T]) Run(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+		b.Close()
+	case <-b.done:
+	}
+}
+
+// Close closes every subscriber channel. Calling it again does nothing.
+func (b *Broadcaster[T]) Close() {
+	b.closeOnce.Do(func() {
+		close(b.done)
+
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.closed = true
+		for _, ch := range b.subscribers {
+			close(ch)
+		}
+		b.subscribers = nil
+	})
+}
@@ -0,0 +1,75 @@
+package v8
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"testing/synctest"
+)
+
+func TestBroadcaster(t *testing.T) {
+	t.Run("every subscriber gets every value", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			b := NewBroadcaster[string]()
+			first, second := b.Subscribe(), b.Subscribe()
+
+			b.Publish("token-1")
+			b.Publish("token-2")
+			b.Close()
+
+			want := []string{"token-1", "token-2"}
+			for _, ch := range []<-chan string{first, second} {
+				if got := drain(ch); !slices.Equal(got, want) {
+					t.Errorf("got %v, want %v", got, want)
+				}
+			}
+		})
+	})
+
+	t.Run("cancelling Run's context closes subscribers and stops Publish", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			b := NewBroadcaster[string]()
+			first, second := b.Subscribe(), b.Subscribe()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			runDone := make(chan struct{})
+			go func() {
+				b.Run(ctx)
+				close(runDone)
+			}()
+
+			b.Publish("before")
+			cancel()
+			<-runDone
+			b.Publish("after")
+
+			for _, ch := range []<-chan string{first, second} {
+				if got := drain(ch); !slices.Equal(got, []string{"before"}) {
+					t.Errorf("got %v, want only the value published before cancel", got)
+				}
+			}
+			if _, ok := <-b.Subscribe(); ok {
+				t.Error("subscribing after cancel should return a closed channel")
+			}
+		})
+	})
+
+	t.Run("Close unblocks a Publish waiting on a slow subscriber", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			b := NewBroadcaster[int]()
+			b.Subscribe() // never read
+
+			published := make(chan struct{})
+			go func() {
+				for i := range subscriberBuffer + 1 {
+					b.Publish(i)
+				}
+				close(published)
+			}()
+			synctest.Wait() // Publish is now stuck on the full buffer
+
+			b.Close()
+			<-published
+		})
+	})
+}
@@ -0,0 +1,218 @@
+package v8
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MapChan sends f applied to every value of in on the returned channel.
+// The returned channel is closed once in is closed.
+func MapChan[T, U any](in <-chan T, f func(T) U) <-chan U {
+	out := make(chan U)
+	go func() {
+		defer close(out)
+		for v := range in {
+			out <- f(v)
+		}
+	}()
+	return out
+}
+
+// FilterChan forwards only the values of in for which pred returns true.
+// The returned channel is closed once in is closed.
+func FilterChan[T any](in <-chan T, pred func(T) bool) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for v := range in {
+			if pred(v) {
+				out <- v
+			}
+		}
+	}()
+	return out
+}
+
+// SampleChan forwards every nth value of in (the nth, 2nth, ...) and drops
+// the rest. The returned channel is closed once in is closed.
+// It panics if n is less than 1.
+func SampleChan[T any](in <-chan T, n int) <-chan T {
+	if n < 1 {
+		panic("v8: SampleChan needs n of at least 1")
+	}
+
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		seen := 0
+		for v := range in {
+			seen++
+			if seen == n {
+				seen = 0
+				out <- v
+			}
+		}
+	}()
+	return out
+}
+
+// Merge fans every value from chans into a single channel.
+// The returned channel is closed once all inputs are closed or ctx is done,
+// whichever happens first; no goroutines are left behind in either case.
+func Merge[T any](ctx context.Context, chans ...<-chan T) <-chan T {
+	out := make(chan T)
+
+	var wg sync.WaitGroup
+	wg.Add(len(chans))
+	for _, ch := range chans {
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case v, ok := <-ch:
+					if !ok {
+						return
+					}
+					select {
+					case out <- v:
+					case <-ctx.Done():
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+// Split distributes the values of in round-robin across n channels.
+// All outputs are closed once in is closed or ctx is done.
+// Each output is unbuffered, so a slow reader holds up the others.
+// It panics if n is less than 1.
+func Split[T any](ctx context.Context, in <-chan T, n int) []<-chan T {
+	if n < 1 {
+		panic("v8: Split needs at least one output")
+	}
+
+	outs := make([]chan T, n)
+	readOnly := make([]<-chan T, n)
+	for i := range outs {
+		outs[i] = make(chan T)
+		readOnly[i] = outs[i]
+	}
+
+	go func() {
+		defer func() {
+			for _, out := range outs {
+				close(out)
+			}
+		}()
+
+		for i := 0; ; i = (i + 1) % n {
+			select {
+			case v, ok := <-in:
+				if !ok {
+					return
+				}
+				select {
+				case outs[i] <- v:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return readOnly
+}
+
+// Tee duplicates every value of in onto both returned channels.
+// A value is handed to both outputs before the next one is read, so the
+// slower reader applies backpressure to the whole stream. Readers that must
+// not hold each other up can wrap an output in their own buffered channel.
+// Both outputs are closed once in is closed or ctx is done.
+func Tee[T any](ctx context.Context, in <-chan T) (<-chan T, <-chan T) {
+	out1 := make(chan T)
+	out2 := make(chan T)
+
+	go func() {
+		defer close(out1)
+		defer close(out2)
+
+		for {
+			var v T
+			var ok bool
+			select {
+			case v, ok = <-in:
+				if !ok {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+
+			// send to whichever output is ready first, then to the other one
+			o1, o2 := out1, out2
+			for o1 != nil || o2 != nil {
+				select {
+				case o1 <- v:
+					o1 = nil
+				case o2 <- v:
+					o2 = nil
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out1, out2
+}
+
+// RecvOrTimeout receives from ch, giving up after d. It returns the value
+// and true, or the zero value and false if d passes first or ch is closed.
+// Under synctest d is measured on the bubble's fake clock.
+//
+// It replaces the hand-written select on ch and time.After in tests. Because
+// a closed channel reads as a timeout, signal by sending a value rather
+// than by closing the channel.
+func RecvOrTimeout[T any](ch <-chan T, d time.Duration) (T, bool) {
+	select {
+	case v, ok := <-ch:
+		return v, ok
+	case <-time.After(d):
+		var zero T
+		return zero, false
+	}
+}
+
+// CollectWithTimeout receives from ch until it has max values, ch is closed,
+// or timeout has passed in total, whichever comes first, and returns what
+// it gathered. Under synctest timeout is measured on the bubble's fake clock.
+func CollectWithTimeout[T any](ch <-chan T, max int, timeout time.Duration) []T {
+	var values []T
+	deadline := time.After(timeout)
+	for len(values) < max {
+		select {
+		case v, ok := <-ch:
+			if !ok {
+				return values
+			}
+			values = append(values, v)
+		case <-deadline:
+			return values
+		}
+	}
+	return values
+}
@@ -0,0 +1,259 @@
+package v8
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+// feed returns a channel that yields values and is then closed.
+func feed[T any](values ...T) <-chan T {
+	ch := make(chan T)
+	go func() {
+		defer close(ch)
+		for _, v := range values {
+			ch <- v
+		}
+	}()
+	return ch
+}
+
+// drain reads ch until it is closed.
+func drain[T any](ch <-chan T) []T {
+	var got []T
+	for v := range ch {
+		got = append(got, v)
+	}
+	return got
+}
+
+func TestMapChan(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		got := drain(MapChan(feed(1, 2, 3), strconv.Itoa))
+		want := []string{"1", "2", "3"}
+
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+}
+
+func TestFilterChan(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		isEven := func(n int) bool { return n%2 == 0 }
+
+		got := drain(FilterChan(feed(1, 2, 3, 4, 5, 6), isEven))
+		want := []int{2, 4, 6}
+
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+}
+
+func TestSampleChan(t *testing.T) {
+	t.Run("forwards every nth value", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			got := drain(SampleChan(feed(1, 2, 3, 4, 5, 6, 7, 8, 9, 10), 3))
+			want := []int{3, 6, 9}
+
+			if !slices.Equal(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	})
+
+	t.Run("panics when n is less than 1", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected a panic")
+			}
+		}()
+		SampleChan(make(chan int), 0)
+	})
+}
+
+func TestChanCombinatorsCloseWithInput(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		in := make(chan int)
+		mapped := MapChan(in, func(n int) int { return n })
+		filtered := FilterChan(in, func(int) bool { return true })
+		sampled := SampleChan(in, 1)
+
+		close(in)
+		synctest.Wait()
+
+		if _, ok := <-mapped; ok {
+			t.Error("MapChan output should be closed")
+		}
+		if _, ok := <-filtered; ok {
+			t.Error("FilterChan output should be closed")
+		}
+		if _, ok := <-sampled; ok {
+			t.Error("SampleChan output should be closed")
+		}
+	})
+}
+
+func TestMerge(t *testing.T) {
+	t.Run("all values arrive and output closes with the inputs", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			got := drain(Merge(context.Background(), feed(1, 2, 3), feed(4, 5), feed(6)))
+			slices.Sort(got)
+			want := []int{1, 2, 3, 4, 5, 6}
+
+			if !slices.Equal(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	})
+
+	t.Run("output closes when the context is cancelled", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			neverClosed := make(chan int)
+
+			out := Merge(ctx, neverClosed, neverClosed, neverClosed)
+			cancel()
+
+			// synctest fails the test if any Merge goroutine is still blocked
+			if _, ok := <-out; ok {
+				t.Error("Merge output should be closed")
+			}
+		})
+	})
+}
+
+func TestSplit(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		outs := Split(context.Background(), feed(1, 2, 3, 4, 5, 6, 7, 8, 9), 3)
+
+		got := make([][]int, len(outs))
+		var wg sync.WaitGroup
+		wg.Add(len(outs))
+		for i, out := range outs {
+			go func() {
+				defer wg.Done()
+				got[i] = drain(out)
+			}()
+		}
+		wg.Wait()
+
+		want := [][]int{{1, 4, 7}, {2, 5, 8}, {3, 6, 9}}
+		for i := range want {
+			if !slices.Equal(got[i], want[i]) {
+				t.Errorf("output %d got %v, want %v", i, got[i], want[i])
+			}
+		}
+	})
+}
+
+func TestTee(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		out1, out2 := Tee(context.Background(), feed("a", "b", "c"))
+
+		var got1, got2 []string
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			got1 = drain(out1)
+		}()
+		go func() {
+			defer wg.Done()
+			got2 = drain(out2)
+		}()
+		wg.Wait()
+
+		want := []string{"a", "b", "c"}
+		if !slices.Equal(got1, want) {
+			t.Errorf("first output got %v, want %v", got1, want)
+		}
+		if !slices.Equal(got2, want) {
+			t.Errorf("second output got %v, want %v", got2, want)
+		}
+	})
+}
+
+func TestRecvOrTimeout(t *testing.T) {
+	t.Run("returns a ready value", func(t *testing.T) {
+		ch := make(chan int, 1)
+		ch <- 42
+
+		got, ok := RecvOrTimeout(ch, time.Second)
+		if !ok || got != 42 {
+			t.Errorf("got %d, %v, want 42, true", got, ok)
+		}
+	})
+
+	t.Run("gives up after the timeout", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			ch := make(chan int)
+
+			start := time.Now()
+			got, ok := RecvOrTimeout(ch, time.Second)
+			if ok || got != 0 {
+				t.Errorf("got %d, %v, want 0, false", got, ok)
+			}
+			if elapsed := time.Since(start); elapsed != time.Second {
+				t.Errorf("took %v, want 1s", elapsed)
+			}
+		})
+	})
+}
+
+func TestCollectWithTimeout(t *testing.T) {
+	t.Run("stops once it has max values", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			ch := make(chan int, 5)
+			for i := 1; i <= 5; i++ {
+				ch <- i
+			}
+
+			start := time.Now()
+			got := CollectWithTimeout(ch, 3, time.Second)
+
+			if want := []int{1, 2, 3}; !slices.Equal(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+			if elapsed := time.Since(start); elapsed != 0 {
+				t.Errorf("took %v, want no waiting", elapsed)
+			}
+		})
+	})
+
+	t.Run("returns what it has when the timeout passes", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			ch := make(chan int)
+			go func() {
+				ch <- 1
+				time.Sleep(400 * time.Millisecond)
+				ch <- 2
+			}()
+
+			start := time.Now()
+			got := CollectWithTimeout(ch, 10, time.Second)
+
+			if want := []int{1, 2}; !slices.Equal(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+			if elapsed := time.Since(start); elapsed != time.Second {
+				t.Errorf("took %v, want 1s", elapsed)
+			}
+		})
+	})
+
+	t.Run("stops when the channel is closed", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			got := CollectWithTimeout(feed(1, 2), 10, time.Second)
+
+			if want := []int{1, 2}; !slices.Equal(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	})
+}
@@ -0,0 +1,71 @@
+package v8
+
+import (
+	"context"
+	"sync"
+)
+
+// CountdownLatch releases everyone waiting on it once CountDown has been
+// called n times. Reset arms it again, so one latch can be reused across
+// rounds of work.
+type CountdownLatch struct {
+	mu    sync.Mutex
+	count int
+	done  chan struct{} // closed when count reaches zero
+}
+
+// NewCountdownLatch returns a latch that opens after n count-downs. A latch
+// with n <= 0 starts open.
+func NewCountdownLatch(n int) *CountdownLatch {
+	l := &CountdownLatch{}
+	l.Reset(n)
+	return l
+}
+
+// CountDown decrements the count, opening the latch when it reaches zero.
+// Count-downs on an open latch are ignored.
+func (l *CountdownLatch) CountDown() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.count == 0 {
+		return
+	}
+	l.count--
+	if l.count == 0 {
+		close(l.done)
+	}
+}
+
+// Count returns the number of count-downs still needed.
+func (l *CountdownLatch) Count() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.count
+}
+
+// Wait blocks until the latch opens, returning nil, or until ctx is done,
+// returning ctx.Err().
+func (l *CountdownLatch) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	done := l.done
+	l.mu.Unlock()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Reset arms the latch to open after another n count-downs. Goroutines
+// already waiting on the previous round keep waiting on it.
+func (l *CountdownLatch) Reset(n int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.count = max(n, 0)
+	l.done = make(chan struct{})
+	if l.count == 0 {
+		close(l.done)
+	}
+}
@@ -0,0 +1,71 @@
+package v8
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+func TestCountdownLatch(t *testing.T) {
+	t.Run("opens on the final count-down", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			latch := NewCountdownLatch(3)
+			for i := 1; i <= 3; i++ {
+				go func() {
+					time.Sleep(time.Duration(i) * time.Second)
+					latch.CountDown()
+				}()
+			}
+
+			start := time.Now()
+			if err := latch.Wait(context.Background()); err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			if elapsed := time.Since(start); elapsed != 3*time.Second {
+				t.Errorf("opened after %v, want 3s", elapsed)
+			}
+			if got := latch.Count(); got != 0 {
+				t.Errorf("got count %d, want 0", got)
+			}
+		})
+	})
+
+	t.Run("Wait returns early when the context is done", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			latch := NewCountdownLatch(2)
+			latch.CountDown()
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			err := latch.Wait(ctx)
+
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("got %v, want %v", err, context.DeadlineExceeded)
+			}
+			if got := latch.Count(); got != 1 {
+				t.Errorf("got count %d, want 1", got)
+			}
+		})
+	})
+
+	t.Run("Reset arms the latch again", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			latch := NewCountdownLatch(1)
+			latch.CountDown()
+			latch.Reset(1)
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			if err := latch.Wait(ctx); err == nil {
+				t.Fatal("Wait should block on a re-armed latch")
+			}
+
+			latch.CountDown()
+			if err := latch.Wait(context.Background()); err != nil {
+				t.Errorf("got %v, want nil", err)
+			}
+		})
+	})
+}
@@ -0,0 +1,59 @@
+package v8
+
+import (
+	"context"
+	"time"
+)
+
+// DebounceChan forwards a value of in only once d has passed without a newer
+// one arriving; values superseded within d are dropped. When in is closed
+// any pending value is sent before the output is closed; when ctx is done
+// the output is closed and a pending value is dropped.
+func DebounceChan[T any](ctx context.Context, in <-chan T, d time.Duration) <-chan T {
+	return DebounceChanWithClock(ctx, RealClock{}, in, d)
+}
+
+// DebounceChanWithClock is DebounceChan with the quiet period measured by
+// clock.
+func DebounceChanWithClock[T any](ctx context.Context, clock Clock, in <-chan T, d time.Duration) <-chan T {
+	out := make(chan T)
+
+	go func() {
+		defer close(out)
+
+		var latest T
+		var quiet <-chan time.Time // nil, and so never ready, while nothing is pending
+
+		emit := func() bool {
+			select {
+			case out <- latest:
+				quiet = nil
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for {
+			select {
+			case v, ok := <-in:
+				if !ok {
+					if quiet != nil {
+						emit()
+					}
+					return
+				}
+				latest = v
+				quiet = clock.After(d)
+			case <-quiet:
+				if !emit() {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,59 @@
+package v8
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+func TestDebounceChan(t *testing.T) {
+	t.Run("emits only the last value of a burst, after the quiet period", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			in := make(chan int)
+			out := DebounceChan(context.Background(), in, time.Second)
+			defer close(in)
+
+			start := time.Now()
+			for i := 1; i <= 5; i++ {
+				in <- i
+				time.Sleep(100 * time.Millisecond)
+			}
+
+			got := <-out
+			if got != 5 {
+				t.Errorf("got %d, want 5", got)
+			}
+			if elapsed := time.Since(start); elapsed != 1400*time.Millisecond {
+				t.Errorf("emitted after %v, want 1.4s", elapsed)
+			}
+			AssertNoReceive(t, out, time.Minute)
+		})
+	})
+
+	t.Run("flushes the pending value when the input closes", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			out := DebounceChan(context.Background(), feed(1, 2, 3), time.Second)
+
+			got := drain(out)
+
+			if want := []int{3}; !slices.Equal(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	})
+
+	t.Run("closes the output when the context is cancelled", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			in := make(chan int)
+			ctx, cancel := context.WithCancel(context.Background())
+			out := DebounceChan(ctx, in, time.Second)
+
+			in <- 1
+			cancel()
+
+			AssertClosed(t, out, time.Second)
+		})
+	})
+}
@@ -0,0 +1,61 @@
+package v8
+
+import (
+	"sync"
+	"time"
+)
+
+// Deduplicator reports whether a value with the same key has been seen
+// within a time window, with the key chosen by the caller.
+type Deduplicator[T any, K comparable] struct {
+	keyFn  func(T) K
+	window time.Duration
+	clock  Clock
+
+	mu        sync.Mutex
+	seen      map[K]time.Time
+	lastPrune time.Time
+}
+
+// NewDeduplicator returns a Deduplicator that remembers keys for window.
+func NewDeduplicator[T any, K comparable](keyFn func(T) K, window time.Duration) *Deduplicator[T, K] {
+	return NewDeduplicatorWithClock(RealClock{}, keyFn, window)
+}
+
+// NewDeduplicatorWithClock is NewDeduplicator with time read from clock.
+func NewDeduplicatorWithClock[T any, K comparable](clock Clock, keyFn func(T) K, window time.Duration) *Deduplicator[T, K] {
+	return &Deduplicator[T, K]{
+		keyFn:     keyFn,
+		window:    window,
+		clock:     clock,
+		seen:      make(map[K]time.Time),
+		lastPrune: clock.Now(),
+	}
+}
+
+// Seen returns true if v's key was recorded less than window ago.
+// Otherwise it records the key and returns false.
+func (d *Deduplicator[T, K]) Seen(v T) bool {
+	key := d.keyFn(v)
+	now := d.clock.Now()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// expired keys are swept at most once per window, so memory stays
+	// bounded by the keys seen in the last two windows
+	if now.Sub(d.lastPrune) >= d.window {
+		for k, at := range d.seen {
+			if now.Sub(at) >= d.window {
+				delete(d.seen, k)
+			}
+		}
+		d.lastPrune = now
+	}
+
+	if at, ok := d.seen[key]; ok && now.Sub(at) < d.window {
+		return true
+	}
+	d.seen[key] = now
+	return false
+}
@@ -0,0 +1,38 @@
+package v8
+
+import (
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+func TestDeduplicator(t *testing.T) {
+	type event struct {
+		UserID int
+		Kind   string
+	}
+
+	synctest.Test(t, func(t *testing.T) {
+		dedup := NewDeduplicator(func(e event) int { return e.UserID }, time.Minute)
+
+		if dedup.Seen(event{UserID: 1, Kind: "login"}) {
+			t.Error("first event should not have been seen")
+		}
+
+		time.Sleep(30 * time.Second)
+		if !dedup.Seen(event{UserID: 1, Kind: "logout"}) {
+			t.Error("event with the same key within the window should have been seen")
+		}
+		if dedup.Seen(event{UserID: 2, Kind: "login"}) {
+			t.Error("event with another key should not have been seen")
+		}
+
+		time.Sleep(31 * time.Second)
+		if dedup.Seen(event{UserID: 1, Kind: "login"}) {
+			t.Error("event after the window expired should not have been seen")
+		}
+		if got := len(dedup.seen); got != 2 {
+			t.Errorf("got %d keys remembered, want 2 after pruning", got)
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package v8
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ExpiringMap holds entries for ttl after they were last set. Get treats
+// expired entries as missing straight away, and Run removes them from
+// memory in the background.
+type ExpiringMap[K comparable, V any] struct {
+	ttl   time.Duration
+	clock Clock
+
+	mu      sync.Mutex
+	entries map[K]expiringEntry[V]
+}
+
+type expiringEntry[V any] struct {
+	value   V
+	expires time.Time
+}
+
+// NewExpiringMap returns an empty map whose entries live for ttl.
+func NewExpiringMap[K comparable, V any](ttl time.Duration) *ExpiringMap[K, V] {
+	return NewExpiringMapWithClock[K, V](RealClock{}, ttl)
+}
+
+// NewExpiringMapWithClock is NewExpiringMap with expiry measured by clock.
+func NewExpiringMapWithClock[K comparable, V any](clock Clock, ttl time.Duration) *ExpiringMap[K, V] {
+	return &ExpiringMap[K, V]{
+		ttl:     ttl,
+		clock:   clock,
+		entries: make(map[K]expiringEntry[V]),
+	}
+}
+
+// Set stores value under key, restarting its ttl.
+func (m *ExpiringMap[K, V]) Set(key K, value V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[key] = expiringEntry[V]{value: value, expires: m.clock.Now().Add(m.ttl)}
+}
+
+// Get returns the value for key. Expired entries are reported as missing
+// even if Run hasn't removed them yet.
+func (m *ExpiringMap[K, V]) Get(key K) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.entries[key]
+	if !ok || !m.clock.Now().Before(entry.expires) {
+		var zero V
+		return zero, false
+	}
+	return entry.value, true
+}
+
+func (m *ExpiringMap[K, V]) Delete(key K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, key)
+}
+
+// Len returns the number of entries held, including expired ones not yet removed.
+func (m *ExpiringMap[K, V]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.entries)
+}
+
+// Run removes expired entries every ttl until ctx is done.
+func (m *ExpiringMap[K, V]) Run(ctx context.Context) {
+	for {
+		select {
+		case <-m.clock.After(m.ttl):
+			m.evictExpired()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (m *ExpiringMap[K, V]) evictExpired() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.clock.Now()
+	for key, entry := range m.entries {
+		if !now.Before(entry.expires) {
+			delete(m.entries, key)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package v8
+
+import (
+	"context"
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+func TestExpiringMap(t *testing.T) {
+	t.Run("Get treats an expired entry as missing", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			sessions := NewExpiringMap[string, int](time.Minute)
+			sessions.Set("ann", 1)
+
+			time.Sleep(59 * time.Second)
+			if got, ok := sessions.Get("ann"); !ok || got != 1 {
+				t.Errorf("got %d, %v, want 1, true", got, ok)
+			}
+
+			time.Sleep(time.Second)
+			if _, ok := sessions.Get("ann"); ok {
+				t.Error("ann should have expired")
+			}
+			if got := sessions.Len(); got != 1 {
+				t.Errorf("got len %d, want 1 until the entry is swept", got)
+			}
+		})
+	})
+
+	t.Run("Run removes expired entries in the background", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			sessions := NewExpiringMap[string, int](time.Minute)
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				sessions.Run(ctx)
+			}()
+
+			sessions.Set("ann", 1)
+			time.Sleep(30 * time.Second)
+			sessions.Set("bob", 2)
+
+			time.Sleep(30 * time.Second) // first sweep: ann has expired
+			synctest.Wait()
+			if got := sessions.Len(); got != 1 {
+				t.Errorf("got len %d, want 1 after the first sweep", got)
+			}
+
+			time.Sleep(time.Minute) // second sweep: bob has expired too
+			synctest.Wait()
+			if got := sessions.Len(); got != 0 {
+				t.Errorf("got len %d, want 0 after the second sweep", got)
+			}
+
+			cancel()
+			<-done
+		})
+	})
+
+	t.Run("Set restarts the ttl", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			sessions := NewExpiringMap[string, int](time.Minute)
+			sessions.Set("ann", 1)
+			time.Sleep(45 * time.Second)
+			sessions.Set("ann", 2)
+			time.Sleep(45 * time.Second)
+
+			if got, ok := sessions.Get("ann"); !ok || got != 2 {
+				t.Errorf("got %d, %v, want 2, true", got, ok)
+			}
+		})
+	})
+}
@@ -0,0 +1,49 @@
+package v8
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// HTTPDoer is the part of *http.Client the timeout examples rely on.
+type HTTPDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// FakeHTTPClient pretends to be a server that takes Latency to respond.
+// Under synctest the wait is on the fake clock, so timeout tests run
+// instantly without starting a real server.
+type FakeHTTPClient struct {
+	Latency    time.Duration
+	StatusCode int
+	Body       string
+}
+
+// Do waits for Latency, or until the request's context is done, and then
+// returns a canned response.
+func (c *FakeHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	timer := time.NewTimer(c.Latency)
+	defer timer.Stop()
+
+	select {
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	case <-timer.C:
+	}
+
+	status := c.StatusCode
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode: status,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader(c.Body)),
+		Request:    req,
+	}, nil
+}
@@ -0,0 +1,63 @@
+package v8
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+var _ HTTPDoer = &FakeHTTPClient{}
+
+func TestFakeHTTPClient(t *testing.T) {
+	t.Run("slow response trips the context deadline", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			client := &FakeHTTPClient{Latency: 2 * time.Second}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://example.com/slow", nil)
+
+			start := time.Now()
+			_, err := client.Do(req)
+
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("got error %v, want %v", err, context.DeadlineExceeded)
+			}
+			if elapsed := time.Since(start); elapsed != 100*time.Millisecond {
+				t.Errorf("gave up after %v, want 100ms", elapsed)
+			}
+		})
+	})
+
+	t.Run("responds after the latency", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			client := &FakeHTTPClient{Latency: 2 * time.Second, Body: "Finally responded!"}
+			req, _ := http.NewRequest(http.MethodGet, "http://example.com/slow", nil)
+
+			start := time.Now()
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			defer resp.Body.Close()
+
+			if elapsed := time.Since(start); elapsed != 2*time.Second {
+				t.Errorf("responded after %v, want 2s", elapsed)
+			}
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("got status %d, want %d", resp.StatusCode, http.StatusOK)
+			}
+			if resp.Status != "200 OK" {
+				t.Errorf("got status %q, want %q", resp.Status, "200 OK")
+			}
+			body, _ := io.ReadAll(resp.Body)
+			if string(body) != "Finally responded!" {
+				t.Errorf("got body %q, want %q", body, "Finally responded!")
+			}
+		})
+	})
+}
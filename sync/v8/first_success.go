@@ -0,0 +1,46 @@
+package v8
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoFuncs is returned by FirstSuccess when it is given nothing to run.
+var ErrNoFuncs = errors.New("v8: no functions to run")
+
+// FirstSuccess runs every fn concurrently and returns the first result
+// without an error, cancelling the context passed to the others. If they all
+// fail it returns their errors joined, in the order the fns were given.
+func FirstSuccess[T any](ctx context.Context, fns ...func(ctx context.Context) (T, error)) (T, error) {
+	var zero T
+	if len(fns) == 0 {
+		return zero, ErrNoFuncs
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		index int
+		value T
+		err   error
+	}
+	// buffered so the losers can still send after we've returned
+	results := make(chan result, len(fns))
+	for i, fn := range fns {
+		go func() {
+			v, err := fn(ctx)
+			results <- result{i, v, err}
+		}()
+	}
+
+	errs := make([]error, len(fns))
+	for range fns {
+		r := <-results
+		if r.err == nil {
+			return r.value, nil
+		}
+		errs[r.index] = r.err
+	}
+	return zero, errors.Join(errs...)
+}
@@ -0,0 +1,95 @@
+package v8
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+// replica returns a read that answers with value, or err, after latency.
+func replica(value string, err error, latency time.Duration) func(context.Context) (string, error) {
+	return func(ctx context.Context) (string, error) {
+		select {
+		case <-time.After(latency):
+			return value, err
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+}
+
+func TestFirstSuccess(t *testing.T) {
+	t.Run("the fastest success wins and the rest are cancelled", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			slowErr := make(chan error, 1)
+			slow := func(ctx context.Context) (string, error) {
+				v, err := replica("slow", nil, 2*time.Second)(ctx)
+				slowErr <- err
+				return v, err
+			}
+
+			start := time.Now()
+			got, err := FirstSuccess(context.Background(),
+				slow,
+				replica("fast", nil, 100*time.Millisecond),
+			)
+
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if got != "fast" {
+				t.Errorf("got %q, want %q", got, "fast")
+			}
+			if elapsed := time.Since(start); elapsed != 100*time.Millisecond {
+				t.Errorf("took %v, want 100ms", elapsed)
+			}
+
+			// without cancellation the slow replica would only answer at 2s,
+			// with a nil error
+			synctest.Wait()
+			if err := <-slowErr; !errors.Is(err, context.Canceled) {
+				t.Errorf("slow replica got %v, want %v", err, context.Canceled)
+			}
+		})
+	})
+
+	t.Run("falls back to a slow success when the fast one fails", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			got, err := FirstSuccess(context.Background(),
+				replica("", errors.New("replica down"), 100*time.Millisecond),
+				replica("slow", nil, time.Second),
+			)
+
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if got != "slow" {
+				t.Errorf("got %q, want %q", got, "slow")
+			}
+		})
+	})
+
+	t.Run("returns every error when all fail", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			errA := errors.New("replica a down")
+			errB := errors.New("replica b down")
+
+			_, err := FirstSuccess(context.Background(),
+				replica("", errA, time.Second),
+				replica("", errB, 100*time.Millisecond),
+			)
+
+			if !errors.Is(err, errA) || !errors.Is(err, errB) {
+				t.Errorf("got %v, want both %v and %v", err, errA, errB)
+			}
+		})
+	})
+
+	t.Run("nothing to run", func(t *testing.T) {
+		if _, err := FirstSuccess[string](context.Background()); !errors.Is(err, ErrNoFuncs) {
+			t.Errorf("got %v, want %v", err, ErrNoFuncs)
+		}
+	})
+}
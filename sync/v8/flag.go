@@ -0,0 +1,51 @@
+package v8
+
+import (
+	"context"
+	"sync"
+)
+
+// Flag is a one-shot signal: once Set, it stays set and everyone waiting on
+// it is released. The zero value is an unset Flag ready to use.
+type Flag struct {
+	once sync.Once
+	mu   sync.Mutex
+	ch   chan struct{} // closed on the first Set
+}
+
+// Set sets the flag. Only the first call has any effect.
+func (f *Flag) Set() {
+	f.once.Do(func() {
+		close(f.done())
+	})
+}
+
+// IsSet reports whether Set has been called.
+func (f *Flag) IsSet() bool {
+	select {
+	case <-f.done():
+		return true
+	default:
+		return false
+	}
+}
+
+// Wait blocks until the flag is set, returning nil, or until ctx is done,
+// returning ctx.Err().
+func (f *Flag) Wait(ctx context.Context) error {
+	select {
+	case <-f.done():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (f *Flag) done() chan struct{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.ch == nil {
+		f.ch = make(chan struct{})
+	}
+	return f.ch
+}
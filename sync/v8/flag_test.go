@@ -0,0 +1,53 @@
+package v8
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+func TestFlag(t *testing.T) {
+	t.Run("Wait returns once the flag is set", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			var ready Flag
+			go func() {
+				time.Sleep(time.Second)
+				ready.Set()
+				ready.Set() // setting twice is fine
+			}()
+
+			if ready.IsSet() {
+				t.Error("flag should start unset")
+			}
+			start := time.Now()
+			if err := ready.Wait(context.Background()); err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			if elapsed := time.Since(start); elapsed != time.Second {
+				t.Errorf("released after %v, want 1s", elapsed)
+			}
+			if !ready.IsSet() {
+				t.Error("flag should be set")
+			}
+		})
+	})
+
+	t.Run("Wait returns when the context is done", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			var ready Flag
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			err := ready.Wait(ctx)
+
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("got %v, want %v", err, context.DeadlineExceeded)
+			}
+			if ready.IsSet() {
+				t.Error("flag should still be unset")
+			}
+		})
+	})
+}
@@ -0,0 +1,62 @@
+package v8
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Histogram counts durations into buckets so percentiles can be estimated
+// without keeping every sample. It is safe for concurrent use.
+type Histogram struct {
+	mu     sync.Mutex
+	bounds []time.Duration // upper bound of each bucket, ascending
+	counts []int64         // one more than bounds, the last counts everything above
+	max    time.Duration
+	total  int64
+}
+
+// NewHistogram returns a Histogram with a bucket for each upper bound.
+func NewHistogram(bounds ...time.Duration) *Histogram {
+	bounds = slices.Clone(bounds)
+	slices.Sort(bounds)
+	return &Histogram{
+		bounds: bounds,
+		counts: make([]int64, len(bounds)+1),
+	}
+}
+
+// Observe records d in the first bucket whose bound is at least d.
+func (h *Histogram) Observe(d time.Duration) {
+	i, _ := slices.BinarySearch(h.bounds, d)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.counts[i]++
+	h.total++
+	h.max = max(h.max, d)
+}
+
+// Quantile returns the upper bound of the bucket holding the q-th quantile,
+// so the real value is at most the returned one. Samples above the largest
+// bound are reported as the largest duration observed.
+// It returns 0 when nothing has been observed.
+func (h *Histogram) Quantile(q float64) time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.total == 0 {
+		return 0
+	}
+
+	rank := int64(math.Ceil(q * float64(h.total)))
+	var seen int64
+	for i, count := range h.counts[:len(h.bounds)] {
+		seen += count
+		if seen >= rank {
+			return h.bounds[i]
+		}
+	}
+	return h.max
+}
@@ -0,0 +1,65 @@
+package v8
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHistogram(t *testing.T) {
+	t.Run("quantiles fall in the expected buckets", func(t *testing.T) {
+		h := NewHistogram(10*time.Millisecond, 50*time.Millisecond, 100*time.Millisecond, 500*time.Millisecond)
+
+		observe := func(d time.Duration, times int) {
+			for i := 0; i < times; i++ {
+				h.Observe(d)
+			}
+		}
+		observe(5*time.Millisecond, 50)
+		observe(30*time.Millisecond, 40)
+		observe(200*time.Millisecond, 10)
+
+		if got := h.Quantile(0.5); got != 10*time.Millisecond {
+			t.Errorf("p50 got %v, want 10ms", got)
+		}
+		if got := h.Quantile(0.9); got != 50*time.Millisecond {
+			t.Errorf("p90 got %v, want 50ms", got)
+		}
+		if got := h.Quantile(0.99); got != 500*time.Millisecond {
+			t.Errorf("p99 got %v, want 500ms", got)
+		}
+	})
+
+	t.Run("values above the largest bucket report the max seen", func(t *testing.T) {
+		h := NewHistogram(time.Millisecond)
+		h.Observe(3 * time.Second)
+
+		if got := h.Quantile(0.5); got != 3*time.Second {
+			t.Errorf("got %v, want 3s", got)
+		}
+	})
+
+	t.Run("empty histogram", func(t *testing.T) {
+		if got := NewHistogram(time.Second).Quantile(0.5); got != 0 {
+			t.Errorf("got %v, want 0", got)
+		}
+	})
+
+	t.Run("it runs safely concurrently", func(t *testing.T) {
+		h := NewHistogram(time.Millisecond)
+
+		var wg sync.WaitGroup
+		wg.Add(100)
+		for i := 0; i < 100; i++ {
+			go func() {
+				defer wg.Done()
+				h.Observe(time.Microsecond)
+			}()
+		}
+		wg.Wait()
+
+		if got := h.Quantile(1); got != time.Millisecond {
+			t.Errorf("got %v, want 1ms", got)
+		}
+	})
+}
@@ -0,0 +1,36 @@
+package v8
+
+import "sync"
+
+// KeyedOnce is a sync.Once per key.
+// The zero value is ready to use.
+type KeyedOnce[K comparable] struct {
+	mu   sync.Mutex
+	done map[K]chan struct{}
+}
+
+// Do calls fn if and only if Do is being called for key for the first time.
+// Like sync.Once, callers for the same key block until that first fn returns,
+// and fn counts as done even if it panics.
+func (k *KeyedOnce[K]) Do(key K, fn func()) {
+	k.mu.Lock()
+	if k.done == nil {
+		k.done = make(map[K]chan struct{})
+	}
+	done, ok := k.done[key]
+	if !ok {
+		done = make(chan struct{})
+		k.done[key] = done
+	}
+	k.mu.Unlock()
+
+	if ok {
+		// waiting on a channel, rather than a sync.Once's mutex, also lets
+		// synctest see these callers as durably blocked
+		<-done
+		return
+	}
+
+	defer close(done)
+	fn()
+}
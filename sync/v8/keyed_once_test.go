@@ -0,0 +1,53 @@
+package v8
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+func TestKeyedOnce(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		var once KeyedOnce[string]
+		keys := []string{"ann", "bob", "chris"}
+		runs := make(map[string]*atomic.Int32, len(keys))
+		for _, key := range keys {
+			runs[key] = new(atomic.Int32)
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 30; i++ {
+			key := keys[i%len(keys)]
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				once.Do(key, func() {
+					time.Sleep(time.Second) // warming a cache takes a while
+					runs[key].Add(1)
+				})
+			}()
+		}
+		wg.Wait()
+
+		for _, key := range keys {
+			if got := runs[key].Load(); got != 1 {
+				t.Errorf("fn for %q ran %d times, want 1", key, got)
+			}
+		}
+	})
+}
+
+func ExampleKeyedOnce() {
+	var once KeyedOnce[int]
+	for _, userID := range []int{1, 2, 1} {
+		once.Do(userID, func() {
+			fmt.Println("warming cache for user", userID)
+		})
+	}
+	// Output:
+	// warming cache for user 1
+	// warming cache for user 2
+}
@@ -0,0 +1,80 @@
+package v8
+
+import (
+	"sync"
+	"time"
+)
+
+// KeyedRateLimiter gives every key its own token bucket of rate tokens,
+// refilled evenly over per. Keys idle long enough to be full again are
+// forgotten, so memory only grows with the keys in recent use.
+type KeyedRateLimiter[K comparable] struct {
+	rate  float64
+	per   time.Duration
+	clock Clock
+
+	mu        sync.Mutex
+	buckets   map[K]*bucket
+	lastPrune time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewKeyedRateLimiter returns a limiter allowing rate calls per key every per.
+// It panics if rate < 1 or per <= 0.
+func NewKeyedRateLimiter[K comparable](rate int, per time.Duration) *KeyedRateLimiter[K] {
+	return NewKeyedRateLimiterWithClock[K](RealClock{}, rate, per)
+}
+
+// NewKeyedRateLimiterWithClock is NewKeyedRateLimiter with time read from clock.
+func NewKeyedRateLimiterWithClock[K comparable](clock Clock, rate int, per time.Duration) *KeyedRateLimiter[K] {
+	if rate < 1 || per <= 0 {
+		panic("v8: KeyedRateLimiter needs a positive rate and period")
+	}
+	return &KeyedRateLimiter[K]{
+		rate:      float64(rate),
+		per:       per,
+		clock:     clock,
+		buckets:   make(map[K]*bucket),
+		lastPrune: clock.Now(),
+	}
+}
+
+// Allow takes a token from key's bucket, returning false if it is empty.
+func (l *KeyedRateLimiter[K]) Allow(key K) bool {
+	now := l.clock.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastPrune) >= l.per {
+		for k, b := range l.buckets {
+			if l.refill(b, now) >= l.rate {
+				delete(l.buckets, k)
+			}
+		}
+		l.lastPrune = now
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.rate, last: now}
+		l.buckets[key] = b
+	}
+	if l.refill(b, now) < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// refill adds the tokens earned since b was last refilled, up to rate.
+func (l *KeyedRateLimiter[K]) refill(b *bucket, now time.Time) float64 {
+	earned := now.Sub(b.last).Seconds() / l.per.Seconds() * l.rate
+	b.tokens = min(l.rate, b.tokens+earned)
+	b.last = now
+	return b.tokens
+}
@@ -0,0 +1,62 @@
+package v8
+
+import (
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+func TestKeyedRateLimiter(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		limiter := NewKeyedRateLimiter[string](3, time.Second)
+
+		for i := range 3 {
+			if !limiter.Allow("ann") {
+				t.Fatalf("call %d for ann should be allowed", i+1)
+			}
+		}
+		if limiter.Allow("ann") {
+			t.Error("ann should be limited after 3 calls")
+		}
+		if !limiter.Allow("bob") {
+			t.Error("bob has his own allowance and should be allowed")
+		}
+
+		// a token comes back every third of a second
+		time.Sleep(400 * time.Millisecond)
+		if !limiter.Allow("ann") {
+			t.Error("ann should be allowed again after a refill")
+		}
+		if limiter.Allow("ann") {
+			t.Error("ann should only have earned one token")
+		}
+
+		// both keys are idle long enough to be full, so they are pruned
+		time.Sleep(2 * time.Second)
+		limiter.Allow("chris")
+		if got := len(limiter.buckets); got != 1 {
+			t.Errorf("got %d buckets, want only chris's after pruning", got)
+		}
+	})
+}
+
+func TestKeyedRateLimiterPanicsOnInvalidArguments(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		rate int
+		per  time.Duration
+	}{
+		{"zero rate", 0, time.Second},
+		{"zero period", 3, 0},
+		{"negative period", 3, -time.Second},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected a panic")
+				}
+			}()
+			NewKeyedRateLimiter[string](tt.rate, tt.per)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package v8
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+// settleTimeout is how long AssertNoGoroutineLeak waits for goroutines
+// started by fn to wind down before reporting a leak.
+const settleTimeout = 100 * time.Millisecond
+
+// AssertNoGoroutineLeak fails the test if fn leaves more goroutines running
+// than there were before it was called.
+func AssertNoGoroutineLeak(t testing.TB, fn func()) {
+	t.Helper()
+
+	before := runtime.NumGoroutine()
+	fn()
+
+	after := runtime.NumGoroutine()
+	deadline := time.Now().Add(settleTimeout)
+	for after > before && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+		after = runtime.NumGoroutine()
+	}
+
+	if after > before {
+		t.Errorf("leaked %d goroutine(s): %d before, %d after", after-before, before, after)
+	}
+}
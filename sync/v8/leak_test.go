@@ -0,0 +1,59 @@
+package v8
+
+import (
+	"sync"
+	"testing"
+)
+
+// spyTB records failures instead of failing the real test.
+type spyTB struct {
+	testing.TB
+	failed bool
+}
+
+func (s *spyTB) Helper() {}
+
+func (s *spyTB) Errorf(format string, args ...any) {
+	s.failed = true
+}
+
+// Fatalf only records the failure, so the helper under test carries on
+// and returns rather than stopping the goroutine.
+func (s *spyTB) Fatalf(format string, args ...any) {
+	s.failed = true
+}
+
+func TestAssertNoGoroutineLeak(t *testing.T) {
+	t.Run("fails when fn leaks a goroutine", func(t *testing.T) {
+		spy := &spyTB{TB: t}
+		block := make(chan struct{})
+		defer close(block) // let the leaked goroutine finish once we're done
+
+		AssertNoGoroutineLeak(spy, func() {
+			go func() {
+				<-block
+			}()
+		})
+
+		if !spy.failed {
+			t.Error("expected a leak to be reported")
+		}
+	})
+
+	t.Run("passes when fn cleans up after itself", func(t *testing.T) {
+		spy := &spyTB{TB: t}
+
+		AssertNoGoroutineLeak(spy, func() {
+			var wg sync.WaitGroup
+			wg.Add(3)
+			for i := 0; i < 3; i++ {
+				go wg.Done()
+			}
+			wg.Wait()
+		})
+
+		if spy.failed {
+			t.Error("expected no leak to be reported")
+		}
+	})
+}
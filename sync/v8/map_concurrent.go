@@ -0,0 +1,60 @@
+package v8
+
+import (
+	"context"
+	"sync"
+)
+
+// MapConcurrent calls f on every item with at most concurrency calls in
+// flight, returning the results in the order of items. The first error
+// cancels the context passed to the other calls, stops new ones from
+// starting and is returned once the calls in flight have finished.
+func MapConcurrent[T, U any](ctx context.Context, items []T, concurrency int, f func(ctx context.Context, item T) (U, error)) ([]U, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	results := make([]U, len(items))
+	sem := make(chan struct{}, max(1, concurrency))
+
+loop:
+	for i, item := range items {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break loop
+		}
+		if ctx.Err() != nil {
+			break // the slot freed up because a call failed
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			u, err := f(ctx, item)
+			if err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			results[i] = u
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err // the caller's ctx was done
+	}
+	return results, nil
+}
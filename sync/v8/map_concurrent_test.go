@@ -0,0 +1,84 @@
+package v8
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+func TestMapConcurrent(t *testing.T) {
+	t.Run("keeps the order and the concurrency limit", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			var inFlight, maxInFlight atomic.Int32
+			square := func(ctx context.Context, n int) (int, error) {
+				now := inFlight.Add(1)
+				for {
+					seen := maxInFlight.Load()
+					if now <= seen || maxInFlight.CompareAndSwap(seen, now) {
+						break
+					}
+				}
+				defer inFlight.Add(-1)
+
+				time.Sleep(time.Duration(10-n) * time.Second) // later items finish first
+				return n * n, nil
+			}
+
+			got, err := MapConcurrent(context.Background(), []int{1, 2, 3, 4, 5, 6}, 3, square)
+
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if want := []int{1, 4, 9, 16, 25, 36}; !slices.Equal(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+			if got := maxInFlight.Load(); got != 3 {
+				t.Errorf("got %d calls in flight at once, want 3", got)
+			}
+		})
+	})
+
+	t.Run("the first error aborts the remaining work", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			errBoom := errors.New("boom")
+			var started, cancelled atomic.Int32
+			f := func(ctx context.Context, n int) (int, error) {
+				started.Add(1)
+				if n == 2 {
+					time.Sleep(time.Second)
+					return 0, errBoom
+				}
+				select {
+				case <-time.After(time.Minute):
+					return n, nil
+				case <-ctx.Done():
+					cancelled.Add(1)
+					return 0, ctx.Err()
+				}
+			}
+
+			start := time.Now()
+			got, err := MapConcurrent(context.Background(), []int{1, 2, 3, 4, 5}, 2, f)
+
+			if !errors.Is(err, errBoom) {
+				t.Errorf("got error %v, want %v", err, errBoom)
+			}
+			if got != nil {
+				t.Errorf("got results %v, want nil", got)
+			}
+			if n := started.Load(); n != 2 {
+				t.Errorf("got %d calls started, want 2", n)
+			}
+			if n := cancelled.Load(); n != 1 {
+				t.Errorf("got %d calls cancelled, want 1", n)
+			}
+			if elapsed := time.Since(start); elapsed != time.Second {
+				t.Errorf("took %v, want 1s", elapsed)
+			}
+		})
+	})
+}
@@ -0,0 +1,71 @@
+package v8
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrMemoizePanicked is returned to callers that were waiting on a Memoize
+// computation that panicked.
+var ErrMemoizePanicked = errors.New("v8: memoized function panicked")
+
+// call is an in-flight or completed Memoize computation.
+type call[V any] struct {
+	wg  sync.WaitGroup
+	val V
+	err error
+}
+
+// Memoize wraps fn so that each key is computed at most once.
+// Concurrent callers asking for a key that is already being computed wait for
+// that in-flight call instead of starting their own, like singleflight.
+// Only successful results are cached; an error is shared with the callers
+// that were waiting and the next call for that key tries again. If fn
+// panics, the panic reaches its caller, waiting callers get
+// ErrMemoizePanicked, and the next call for that key tries again.
+func Memoize[K comparable, V any](fn func(K) (V, error)) func(K) (V, error) {
+	var (
+		mu       sync.Mutex
+		cache    = make(map[K]V)
+		inflight = make(map[K]*call[V])
+	)
+
+	return func(key K) (V, error) {
+		mu.Lock()
+		if v, ok := cache[key]; ok {
+			mu.Unlock()
+			return v, nil
+		}
+		if c, ok := inflight[key]; ok {
+			mu.Unlock()
+			c.wg.Wait()
+			return c.val, c.err
+		}
+
+		c := new(call[V])
+		c.wg.Add(1)
+		inflight[key] = c
+		mu.Unlock()
+
+		// the cleanup is deferred so a panicking fn can't leave waiters
+		// blocked, or the key stuck in inflight, forever
+		finished := false
+		defer func() {
+			if !finished {
+				c.err = ErrMemoizePanicked
+			}
+			mu.Lock()
+			if c.err == nil {
+				cache[key] = c.val
+			}
+			delete(inflight, key)
+			mu.Unlock()
+			c.wg.Done()
+		}()
+
+		c.val, c.err = fn(key)
+		finished = true
+
+		return c.val, c.err
+	}
+}
@@ -0,0 +1,128 @@
+package v8
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+func TestMemoize(t *testing.T) {
+	t.Run("concurrent callers for the same key compute once", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			var calls atomic.Int32
+			upper := Memoize(func(s string) (string, error) {
+				calls.Add(1)
+				time.Sleep(time.Second) // an expensive computation
+				return strings.ToUpper(s), nil
+			})
+
+			const callers = 10
+			results := make([]string, callers)
+
+			var wg sync.WaitGroup
+			wg.Add(callers)
+			for i := 0; i < callers; i++ {
+				go func() {
+					defer wg.Done()
+					results[i], _ = upper("gopher")
+				}()
+			}
+			wg.Wait()
+
+			if got := calls.Load(); got != 1 {
+				t.Errorf("fn called %d times, want 1", got)
+			}
+			for i, got := range results {
+				if got != "GOPHER" {
+					t.Errorf("caller %d got %q, want %q", i, got, "GOPHER")
+				}
+			}
+		})
+	})
+
+	t.Run("results are cached per key", func(t *testing.T) {
+		var calls atomic.Int32
+		double := Memoize(func(n int) (int, error) {
+			calls.Add(1)
+			return n * 2, nil
+		})
+
+		double(1)
+		double(2)
+		got, _ := double(1)
+
+		if got != 2 {
+			t.Errorf("got %d, want 2", got)
+		}
+		if calls.Load() != 2 {
+			t.Errorf("fn called %d times, want 2", calls.Load())
+		}
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		var calls atomic.Int32
+		errBoom := errors.New("boom")
+		flaky := Memoize(func(n int) (int, error) {
+			if calls.Add(1) == 1 {
+				return 0, errBoom
+			}
+			return n, nil
+		})
+
+		if _, err := flaky(1); !errors.Is(err, errBoom) {
+			t.Fatalf("got error %v, want %v", err, errBoom)
+		}
+		got, err := flaky(1)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if got != 1 {
+			t.Errorf("got %d, want 1", got)
+		}
+	})
+
+	t.Run("a panicking fn doesn't block later callers", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			var calls atomic.Int32
+			release := make(chan struct{})
+			fragile := Memoize(func(n int) (int, error) {
+				if calls.Add(1) == 1 {
+					<-release
+					panic("boom")
+				}
+				return n, nil
+			})
+
+			panicked := make(chan any)
+			go func() {
+				defer func() { panicked <- recover() }()
+				fragile(1)
+			}()
+			synctest.Wait() // the first call is now in flight
+
+			waiterErr := make(chan error)
+			go func() {
+				_, err := fragile(1)
+				waiterErr <- err
+			}()
+			synctest.Wait()
+
+			close(release)
+			if r := <-panicked; r != "boom" {
+				t.Errorf("got panic %v, want boom", r)
+			}
+			if err := <-waiterErr; !errors.Is(err, ErrMemoizePanicked) {
+				t.Errorf("waiter got error %v, want %v", err, ErrMemoizePanicked)
+			}
+
+			got, err := fragile(1)
+			if err != nil || got != 1 {
+				t.Errorf("got %d, %v, want 1, nil", got, err)
+			}
+		})
+	})
+}
@@ -0,0 +1,57 @@
+package v8
+
+import "sync"
+
+// Observable holds a value and tells subscribers whenever it is Set.
+// The zero value is ready to use and holds T's zero value.
+type Observable[T any] struct {
+	mu          sync.Mutex
+	value       T
+	subscribers map[chan T]struct{}
+}
+
+// Get returns the current value.
+func (o *Observable[T]) Get() T {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.value
+}
+
+// Set stores value and sends it to every subscriber. Set never waits: a
+// subscriber that has fallen subscriberBuffer values behind misses this one.
+func (o *Observable[T]) Set(value T) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.value = value
+	for ch := range o.subscribers {
+		select {
+		case ch <- value:
+		default:
+		}
+	}
+}
+
+// Subscribe returns a channel receiving every value Set after this call,
+// and a function that unsubscribes and closes the channel. Calling the
+// unsubscribe function more than once is fine.
+func (o *Observable[T]) Subscribe() (<-chan T, func()) {
+	ch := make(chan T, subscriberBuffer)
+
+	o.mu.Lock()
+	if o.subscribers == nil {
+		o.subscribers = make(map[chan T]struct{})
+	}
+	o.subscribers[ch] = struct{}{}
+	o.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			o.mu.Lock()
+			delete(o.subscribers, ch)
+			o.mu.Unlock()
+			close(ch)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package v8
+
+import (
+	"slices"
+	"testing"
+	"testing/synctest"
+)
+
+func TestObservable(t *testing.T) {
+	t.Run("subscribers get every Set until they unsubscribe", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			var temperature Observable[int]
+			first, unsubscribeFirst := temperature.Subscribe()
+			second, unsubscribeSecond := temperature.Subscribe()
+			defer unsubscribeSecond()
+
+			temperature.Set(20)
+			temperature.Set(21)
+			unsubscribeFirst()
+			temperature.Set(22)
+
+			if got := temperature.Get(); got != 22 {
+				t.Errorf("got %d, want 22", got)
+			}
+
+			// first is closed by unsubscribing, so drain returns what it had
+			if got, want := drain(first), []int{20, 21}; !slices.Equal(got, want) {
+				t.Errorf("first subscriber got %v, want %v", got, want)
+			}
+
+			var got []int
+			for range 3 {
+				got = append(got, <-second)
+			}
+			if want := []int{20, 21, 22}; !slices.Equal(got, want) {
+				t.Errorf("second subscriber got %v, want %v", got, want)
+			}
+		})
+	})
+
+	t.Run("a slow subscriber doesn't block Set", func(t *testing.T) {
+		var counter Observable[int]
+		_, unsubscribe := counter.Subscribe() // never read
+		defer unsubscribe()
+
+		for i := range subscriberBuffer * 2 {
+			counter.Set(i)
+		}
+
+		if got := counter.Get(); got != subscriberBuffer*2-1 {
+			t.Errorf("got %d, want %d", got, subscriberBuffer*2-1)
+		}
+	})
+}
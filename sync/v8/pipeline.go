@@ -0,0 +1,117 @@
+package v8
+
+import "context"
+
+// Stage transforms one channel into another. A stage should stop and close
+// its output when its input is closed or ctx is done.
+type Stage[T any] func(ctx context.Context, in <-chan T) <-chan T
+
+// Pipeline feeds source through stages in order and returns the output of
+// the last one. With no stages it returns source.
+func Pipeline[T any](ctx context.Context, source <-chan T, stages ...Stage[T]) <-chan T {
+	out := source
+	for _, stage := range stages {
+		out = stage(ctx, out)
+	}
+	return out
+}
+
+// MapStage returns a Stage that sends f applied to every value.
+func MapStage[T any](f func(T) T) Stage[T] {
+	return func(ctx context.Context, in <-chan T) <-chan T {
+		out := make(chan T)
+		go func() {
+			defer close(out)
+			forward(ctx, in, out, func(v T) (T, bool) { return f(v), true })
+		}()
+		return out
+	}
+}
+
+// FilterStage returns a Stage that forwards only the values pred accepts.
+func FilterStage[T any](pred func(T) bool) Stage[T] {
+	return func(ctx context.Context, in <-chan T) <-chan T {
+		out := make(chan T)
+		go func() {
+			defer close(out)
+			forward(ctx, in, out, func(v T) (T, bool) { return v, pred(v) })
+		}()
+		return out
+	}
+}
+
+// forward sends step's output for each value of in to out until in is
+// closed or ctx is done. Values for which step returns false are skipped.
+func forward[T any](ctx context.Context, in <-chan T, out chan<- T, step func(T) (T, bool)) {
+	for {
+		select {
+		case v, ok := <-in:
+			if !ok {
+				return
+			}
+			v, keep := step(v)
+			if !keep {
+				continue
+			}
+			select {
+			case out <- v:
+			case <-ctx.Done():
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// StageWithErrors is a Stage that can also fail on individual values. It
+// reports failures on its error channel and closes both outputs when its
+// input is closed or ctx is done.
+type StageWithErrors[T any] func(ctx context.Context, in <-chan T) (<-chan T, <-chan error)
+
+// PipelineWithErrors is Pipeline for stages that can fail. It returns the
+// values from the last stage and every stage's errors merged onto one
+// channel. Callers must keep reading both channels, or cancel ctx, so that
+// no stage is left blocked.
+func PipelineWithErrors[T any](ctx context.Context, source <-chan T, stages ...StageWithErrors[T]) (<-chan T, <-chan error) {
+	out := source
+	errs := make([]<-chan error, 0, len(stages))
+	for _, stage := range stages {
+		var stageErrs <-chan error
+		out, stageErrs = stage(ctx, out)
+		errs = append(errs, stageErrs)
+	}
+	return out, MergeErrors(errs...)
+}
+
+// MapStageWithErrors returns a StageWithErrors that sends f applied to every
+// value. When f fails the error is sent on the error channel and the value
+// is dropped.
+func MapStageWithErrors[T any](f func(T) (T, error)) StageWithErrors[T] {
+	return func(ctx context.Context, in <-chan T) (<-chan T, <-chan error) {
+		out := make(chan T)
+		errs := make(chan error)
+		go func() {
+			defer close(out)
+			defer close(errs)
+			forward(ctx, in, out, func(v T) (T, bool) {
+				v, err := f(v)
+				if err == nil {
+					return v, true
+				}
+				select {
+				case errs <- err:
+				case <-ctx.Done():
+				}
+				return v, false
+			})
+		}()
+		return out, errs
+	}
+}
+
+// MergeErrors fans the errors from chans into a single channel, which is
+// closed once all of them are closed.
+func MergeErrors(chans ...<-chan error) <-chan error {
+	return Merge(context.Background(), chans...)
+}
@@ -0,0 +1,101 @@
+package v8
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"testing/synctest"
+)
+
+func TestPipeline(t *testing.T) {
+	double := MapStage(func(n int) int { return n * 2 })
+	overFive := FilterStage(func(n int) bool { return n > 5 })
+
+	t.Run("chains the stages in order", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			out := Pipeline(context.Background(), feed(1, 2, 3, 4), double, overFive)
+
+			got := drain(out)
+			if want := []int{6, 8}; !slices.Equal(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	})
+
+	t.Run("no stages passes the source through", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			got := drain(Pipeline(context.Background(), feed(1, 2)))
+			if want := []int{1, 2}; !slices.Equal(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	})
+
+	t.Run("cancelling closes the output and stops every stage", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			source := make(chan int) // never closed
+			out := Pipeline(ctx, source, double, overFive)
+
+			source <- 3
+			if got := <-out; got != 6 {
+				t.Errorf("got %d, want 6", got)
+			}
+
+			cancel()
+			if _, ok := <-out; ok {
+				t.Error("output should be closed after cancel")
+			}
+			// synctest.Test panics if a stage is left blocked when we return
+		})
+	})
+}
+
+func TestPipelineWithErrors(t *testing.T) {
+	errOdd := errors.New("odd number")
+	evenOnly := MapStageWithErrors(func(n int) (int, error) {
+		if n%2 != 0 {
+			return 0, fmt.Errorf("%d: %w", n, errOdd)
+		}
+		return n, nil
+	})
+	double := MapStageWithErrors(func(n int) (int, error) { return n * 2, nil })
+
+	synctest.Test(t, func(t *testing.T) {
+		out, errs := PipelineWithErrors(context.Background(), feed(2, 3, 4), evenOnly, double)
+
+		var gotErrs []error
+		errsDone := make(chan struct{})
+		go func() {
+			defer close(errsDone)
+			gotErrs = drain(errs)
+		}()
+
+		got := drain(out)
+		<-errsDone
+
+		if want := []int{4, 8}; !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+		if len(gotErrs) != 1 || !errors.Is(gotErrs[0], errOdd) {
+			t.Fatalf("got errors %v, want one wrapping %v", gotErrs, errOdd)
+		}
+		if gotErrs[0].Error() != "3: odd number" {
+			t.Errorf("got %q, want %q", gotErrs[0], "3: odd number")
+		}
+	})
+}
+
+func TestMergeErrors(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		errA, errB := errors.New("a"), errors.New("b")
+
+		got := drain(MergeErrors(feed(errA), feed(errB), feed[error]()))
+
+		if len(got) != 2 || !slices.Contains(got, errA) || !slices.Contains(got, errB) {
+			t.Errorf("got %v, want a and b", got)
+		}
+	})
+}
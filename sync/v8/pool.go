@@ -0,0 +1,31 @@
+package v8
+
+import "sync"
+
+// Pool is a typed wrapper around sync.Pool.
+type Pool[T any] struct {
+	pool  sync.Pool
+	reset func(T)
+}
+
+// NewPool returns a Pool that creates values with factory and cleans them
+// with reset before they go back into the pool.
+func NewPool[T any](factory func() T, reset func(T)) *Pool[T] {
+	return &Pool[T]{
+		pool:  sync.Pool{New: func() any { return factory() }},
+		reset: reset,
+	}
+}
+
+// Get returns a value from the pool, creating one if the pool is empty.
+func (p *Pool[T]) Get() T {
+	return p.pool.Get().(T)
+}
+
+// Put resets value and returns it to the pool for reuse.
+func (p *Pool[T]) Put(value T) {
+	if p.reset != nil {
+		p.reset(value)
+	}
+	p.pool.Put(value)
+}
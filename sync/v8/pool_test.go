@@ -0,0 +1,85 @@
+package v8
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPool(t *testing.T) {
+	t.Run("reset is applied before a value is reused", func(t *testing.T) {
+		resets := 0
+		pool := NewPool(
+			func() *bytes.Buffer { return new(bytes.Buffer) },
+			func(b *bytes.Buffer) {
+				resets++
+				b.Reset()
+			},
+		)
+
+		buf := pool.Get()
+		buf.WriteString("dirty")
+		pool.Put(buf)
+
+		if resets != 1 {
+			t.Errorf("got %d resets, want 1", resets)
+		}
+
+		// sync.Pool may hand back the same buffer or a fresh one,
+		// either way it must be empty.
+		if got := pool.Get(); got.Len() != 0 {
+			t.Errorf("got a buffer containing %q, want an empty one", got.String())
+		}
+	})
+
+	t.Run("nil reset is allowed", func(t *testing.T) {
+		pool := NewPool(func() int { return 42 }, nil)
+		got := pool.Get()
+		pool.Put(got)
+
+		if got != 42 {
+			t.Errorf("got %d, want 42", got)
+		}
+		if got := pool.Get(); got != 42 {
+			t.Errorf("got %d after Put, want 42", got)
+		}
+	})
+
+	t.Run("Put with a nil reset leaves the value unchanged", func(t *testing.T) {
+		pool := NewPool(func() *bytes.Buffer { return new(bytes.Buffer) }, nil)
+		buf := pool.Get()
+		buf.WriteString("kept")
+
+		pool.Put(buf)
+
+		if got := buf.String(); got != "kept" {
+			t.Errorf("got %q, want %q", got, "kept")
+		}
+	})
+}
+
+var sink *bytes.Buffer
+
+func BenchmarkPool(b *testing.B) {
+	pool := NewPool(
+		func() *bytes.Buffer { return bytes.NewBuffer(make([]byte, 0, 4096)) },
+		func(b *bytes.Buffer) { b.Reset() },
+	)
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		buf := pool.Get()
+		buf.WriteString("hello, pool")
+		sink = buf
+		pool.Put(buf)
+	}
+}
+
+func BenchmarkFreshAllocation(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		buf := bytes.NewBuffer(make([]byte, 0, 4096))
+		buf.WriteString("hello, pool")
+		sink = buf
+	}
+}
@@ -0,0 +1,41 @@
+package v8
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNoAttempts is returned by Retry when maxAttempts is less than 1.
+var ErrNoAttempts = errors.New("v8: retry needs at least one attempt")
+
+// Retry calls fn until it succeeds, it has been called maxAttempts times, or
+// ctx is done. After the nth failed attempt it waits backoff(n) before trying
+// again. The returned error wraps the last error from fn, and the context's
+// error too if Retry gave up because ctx was done. If maxAttempts is less
+// than 1, fn isn't called and Retry returns ErrNoAttempts.
+func Retry(ctx context.Context, maxAttempts int, backoff func(attempt int) time.Duration, fn func(ctx context.Context) error) error {
+	if maxAttempts < 1 {
+		return ErrNoAttempts
+	}
+
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if err = fn(ctx); err == nil {
+			return nil
+		}
+		if attempt == maxAttempts {
+			break
+		}
+
+		timer := time.NewTimer(backoff(attempt))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("retry cancelled after %d attempts: %w (last error: %w)", attempt, ctx.Err(), err)
+		}
+	}
+	return fmt.Errorf("retry failed after %d attempts: %w", maxAttempts, err)
+}
@@ -0,0 +1,126 @@
+package v8
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	errFlaky := errors.New("flaky")
+	constant := func(int) time.Duration { return time.Second }
+
+	// failTimes returns a fn that fails n times before succeeding,
+	// and a pointer to how many times it was called.
+	failTimes := func(n int) (func(context.Context) error, *int) {
+		calls := 0
+		return func(context.Context) error {
+			calls++
+			if calls <= n {
+				return errFlaky
+			}
+			return nil
+		}, &calls
+	}
+
+	t.Run("success on the first try", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			fn, calls := failTimes(0)
+			start := time.Now()
+
+			err := Retry(context.Background(), 3, constant, fn)
+
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if *calls != 1 {
+				t.Errorf("got %d calls, want 1", *calls)
+			}
+			if elapsed := time.Since(start); elapsed != 0 {
+				t.Errorf("waited %v, want no waiting", elapsed)
+			}
+		})
+	})
+
+	t.Run("success after retries", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			fn, calls := failTimes(2)
+			exponential := func(attempt int) time.Duration {
+				return time.Duration(1<<attempt) * 100 * time.Millisecond
+			}
+			start := time.Now()
+
+			err := Retry(context.Background(), 5, exponential, fn)
+
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if *calls != 3 {
+				t.Errorf("got %d calls, want 3", *calls)
+			}
+			// 200ms after the first failure, 400ms after the second
+			if elapsed := time.Since(start); elapsed != 600*time.Millisecond {
+				t.Errorf("waited %v, want 600ms", elapsed)
+			}
+		})
+	})
+
+	t.Run("attempts exhausted", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			fn, calls := failTimes(10)
+
+			err := Retry(context.Background(), 3, constant, fn)
+
+			if !errors.Is(err, errFlaky) {
+				t.Errorf("got error %v, want it to wrap %v", err, errFlaky)
+			}
+			if got, want := err.Error(), "retry failed after 3 attempts: flaky"; got != want {
+				t.Errorf("got error %q, want %q", got, want)
+			}
+			if *calls != 3 {
+				t.Errorf("got %d calls, want 3", *calls)
+			}
+		})
+	})
+
+	t.Run("cancelled during the backoff", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			fn, calls := failTimes(10)
+			ctx, cancel := context.WithTimeout(context.Background(), 1500*time.Millisecond)
+			defer cancel()
+			start := time.Now()
+
+			err := Retry(ctx, 5, constant, fn)
+
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("got error %v, want it to wrap %v", err, context.DeadlineExceeded)
+			}
+			if !errors.Is(err, errFlaky) {
+				t.Errorf("got error %v, want it to wrap %v", err, errFlaky)
+			}
+			if *calls != 2 {
+				t.Errorf("got %d calls, want 2", *calls)
+			}
+			if elapsed := time.Since(start); elapsed != 1500*time.Millisecond {
+				t.Errorf("gave up after %v, want 1.5s", elapsed)
+			}
+		})
+	})
+
+	t.Run("no attempts allowed", func(t *testing.T) {
+		for _, maxAttempts := range []int{0, -1} {
+			fn, calls := failTimes(0)
+
+			err := Retry(context.Background(), maxAttempts, constant, fn)
+
+			if !errors.Is(err, ErrNoAttempts) {
+				t.Errorf("maxAttempts %d: got error %v, want %v", maxAttempts, err, ErrNoAttempts)
+			}
+			if *calls != 0 {
+				t.Errorf("maxAttempts %d: got %d calls, want 0", maxAttempts, *calls)
+			}
+		}
+	})
+}
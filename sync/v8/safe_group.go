@@ -0,0 +1,34 @@
+package v8
+
+import "sync"
+
+// SafeGroup is a sync.WaitGroup that recovers panics in the goroutines it
+// starts, so a test can assert on them rather than crash.
+type SafeGroup struct {
+	wg        sync.WaitGroup
+	once      sync.Once
+	recovered any
+}
+
+// Go runs fn in a new goroutine.
+func (g *SafeGroup) Go(fn func()) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		defer func() {
+			if r := recover(); r != nil {
+				g.once.Do(func() {
+					g.recovered = r
+				})
+			}
+		}()
+		fn()
+	}()
+}
+
+// Wait blocks until every fn has returned, then returns the value of the
+// first panic recovered, or nil if none of them panicked.
+func (g *SafeGroup) Wait() (recovered any) {
+	g.wg.Wait()
+	return g.recovered
+}
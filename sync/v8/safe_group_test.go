@@ -0,0 +1,37 @@
+package v8
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+func TestSafeGroup(t *testing.T) {
+	t.Run("clean run returns nil", func(t *testing.T) {
+		var g SafeGroup
+		var ran atomic.Int32
+		for i := 0; i < 5; i++ {
+			g.Go(func() {
+				ran.Add(1)
+			})
+		}
+
+		if got := g.Wait(); got != nil {
+			t.Errorf("got %v, want nil", got)
+		}
+		if ran.Load() != 5 {
+			t.Errorf("got %d runs, want 5", ran.Load())
+		}
+	})
+
+	t.Run("a panicking func is recovered and returned", func(t *testing.T) {
+		var g SafeGroup
+		g.Go(func() {})
+		g.Go(func() {
+			panic("handler exploded")
+		})
+
+		if got := g.Wait(); got != "handler exploded" {
+			t.Errorf("got %v, want %q", got, "handler exploded")
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package v8
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+)
+
+// DefaultShutdownGrace is how long RunServer waits for in-flight requests.
+const DefaultShutdownGrace = 5 * time.Second
+
+// RunServer serves handler on addr until ctx is done, then shuts the server
+// down gracefully, giving in-flight requests DefaultShutdownGrace to finish.
+func RunServer(ctx context.Context, addr string, handler http.Handler) error {
+	return RunServerWithGrace(ctx, addr, handler, DefaultShutdownGrace)
+}
+
+// RunServerWithGrace is RunServer with a configurable grace period.
+// If requests are still running when it ends, the remaining connections are
+// closed and context.DeadlineExceeded is returned.
+func RunServerWithGrace(ctx context.Context, addr string, handler http.Handler, grace time.Duration) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	srv := &http.Server{Handler: handler}
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.Serve(ln)
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), grace)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		srv.Close()
+		return err
+	}
+
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package v8
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// freeAddr finds a port nothing is listening on.
+func freeAddr(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	return ln.Addr().String()
+}
+
+// waitForServer polls addr until the server accepts connections.
+func waitForServer(t *testing.T, addr string) {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		if conn, err := net.Dial("tcp", addr); err == nil {
+			conn.Close()
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("server on %s never started", addr)
+}
+
+func TestRunServer(t *testing.T) {
+	t.Run("in-flight requests finish before it returns", func(t *testing.T) {
+		addr := freeAddr(t)
+		requestStarted := make(chan struct{})
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(requestStarted)
+			time.Sleep(100 * time.Millisecond)
+			w.Write([]byte("done"))
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		serverErr := make(chan error, 1)
+		go func() {
+			serverErr <- RunServerWithGrace(ctx, addr, handler, time.Second)
+		}()
+		waitForServer(t, addr)
+
+		client := &http.Client{}
+		defer client.CloseIdleConnections()
+
+		statusCode := make(chan int, 1)
+		go func() {
+			resp, err := client.Get("http://" + addr)
+			if err != nil {
+				statusCode <- 0
+				return
+			}
+			resp.Body.Close()
+			statusCode <- resp.StatusCode
+		}()
+
+		<-requestStarted
+		cancel()
+
+		if err := <-serverErr; err != nil {
+			t.Errorf("unexpected error %v", err)
+		}
+		if got := <-statusCode; got != http.StatusOK {
+			t.Errorf("got status %d, want %d", got, http.StatusOK)
+		}
+	})
+
+	t.Run("gives up after the grace period", func(t *testing.T) {
+		addr := freeAddr(t)
+		release := make(chan struct{})
+		defer close(release)
+		requestStarted := make(chan struct{})
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(requestStarted)
+			<-release
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		serverErr := make(chan error, 1)
+		go func() {
+			serverErr <- RunServerWithGrace(ctx, addr, handler, 50*time.Millisecond)
+		}()
+		waitForServer(t, addr)
+
+		client := &http.Client{}
+		defer client.CloseIdleConnections()
+
+		requestDone := make(chan struct{})
+		defer func() { <-requestDone }()
+		go func() {
+			defer close(requestDone)
+			if resp, err := client.Get("http://" + addr); err == nil {
+				resp.Body.Close()
+			}
+		}()
+		<-requestStarted
+
+		start := time.Now()
+		cancel()
+
+		err, ok := RecvOrTimeout(serverErr, 2*time.Second)
+		if !ok {
+			t.Fatal("server did not stop after the grace period")
+		}
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("took %v to stop, want about 50ms", elapsed)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package v8
+
+import (
+	"sync"
+	"time"
+)
+
+// SlidingWindowCounter counts increments over the last window of time.
+// The window is split into a ring of buckets, so increments age out one
+// bucket at a time: Count is accurate to window/buckets.
+type SlidingWindowCounter struct {
+	clock      Clock
+	start      time.Time
+	bucketSize time.Duration
+
+	mu      sync.Mutex
+	counts  []int64
+	periods []int64 // which period since start each bucket currently counts
+}
+
+// NewSlidingWindowCounter returns a counter over window split into buckets.
+// It panics if buckets < 1 or window is shorter than buckets nanoseconds.
+func NewSlidingWindowCounter(window time.Duration, buckets int) *SlidingWindowCounter {
+	return NewSlidingWindowCounterWithClock(RealClock{}, window, buckets)
+}
+
+// NewSlidingWindowCounterWithClock is NewSlidingWindowCounter with time read from clock.
+func NewSlidingWindowCounterWithClock(clock Clock, window time.Duration, buckets int) *SlidingWindowCounter {
+	if buckets < 1 || window < time.Duration(buckets) {
+		panic("v8: SlidingWindowCounter needs at least one bucket of at least 1ns")
+	}
+	return &SlidingWindowCounter{
+		clock:      clock,
+		start:      clock.Now(),
+		bucketSize: window / time.Duration(buckets),
+		counts:     make([]int64, buckets),
+		periods:    make([]int64, buckets),
+	}
+}
+
+// Inc records one increment now.
+func (c *SlidingWindowCounter) Inc() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	period := c.period()
+	i := period % int64(len(c.counts))
+	if c.periods[i] != period {
+		c.periods[i] = period // the bucket last counted a period that has aged out
+		c.counts[i] = 0
+	}
+	c.counts[i]++
+}
+
+// Count returns the number of increments within the last window.
+func (c *SlidingWindowCounter) Count() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	period := c.period()
+	oldest := period - int64(len(c.counts)) + 1
+	var total int64
+	for i, p := range c.periods {
+		if p >= oldest && p <= period {
+			total += c.counts[i]
+		}
+	}
+	return total
+}
+
+func (c *SlidingWindowCounter) period() int64 {
+	return int64(c.clock.Now().Sub(c.start) / c.bucketSize)
+}
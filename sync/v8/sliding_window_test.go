@@ -0,0 +1,38 @@
+package v8
+
+import (
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+func TestSlidingWindowCounter(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		c := NewSlidingWindowCounter(10*time.Second, 10)
+		assertCount := func(t *testing.T, want int64) {
+			t.Helper()
+			if got := c.Count(); got != want {
+				t.Errorf("got %d, want %d", got, want)
+			}
+		}
+
+		c.Inc()
+		c.Inc()
+		assertCount(t, 2)
+
+		time.Sleep(5 * time.Second)
+		c.Inc()
+		assertCount(t, 3)
+
+		// the first two increments are now 10s old and out of the window
+		time.Sleep(5 * time.Second)
+		assertCount(t, 1)
+
+		// the bucket from 0s is reused; its old count must not come back
+		c.Inc()
+		assertCount(t, 2)
+
+		time.Sleep(time.Minute)
+		assertCount(t, 0)
+	})
+}
@@ -0,0 +1,50 @@
+package v8
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Snapshotted holds a value that is read often and replaced rarely, such as
+// config. Readers load the current snapshot without taking a lock; writers
+// build a new value and swap it in, serialised with each other. A stored
+// value must be treated as immutable: change a copy and Store that instead.
+// The zero value holds T's zero value.
+type Snapshotted[T any] struct {
+	writeMu sync.Mutex
+	current atomic.Pointer[T]
+}
+
+// NewSnapshotted returns a Snapshotted holding initial.
+func NewSnapshotted[T any](initial T) *Snapshotted[T] {
+	s := &Snapshotted[T]{}
+	s.current.Store(&initial)
+	return s
+}
+
+// Load returns the current snapshot.
+func (s *Snapshotted[T]) Load() T {
+	p := s.current.Load()
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
+
+// Store replaces the snapshot. Readers see either the old value or value,
+// never a mix of the two.
+func (s *Snapshotted[T]) Store(value T) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	s.current.Store(&value)
+}
+
+// Update stores the result of fn applied to the current snapshot. Updates
+// don't interleave, so none are lost to a concurrent Store or Update.
+func (s *Snapshotted[T]) Update(fn func(T) T) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	next := fn(s.Load())
+	s.current.Store(&next)
+}
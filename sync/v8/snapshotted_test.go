@@ -0,0 +1,75 @@
+package v8
+
+import (
+	"sync"
+	"testing"
+)
+
+type snapshotConfig struct {
+	Version  int
+	Checksum int // always Version * 10, so a torn read would show
+}
+
+func TestSnapshotted(t *testing.T) {
+	t.Run("Load returns the latest Store", func(t *testing.T) {
+		config := NewSnapshotted(snapshotConfig{Version: 1, Checksum: 10})
+		config.Store(snapshotConfig{Version: 2, Checksum: 20})
+
+		if got, want := config.Load(), (snapshotConfig{Version: 2, Checksum: 20}); got != want {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("the zero value holds the zero snapshot", func(t *testing.T) {
+		var config Snapshotted[snapshotConfig]
+
+		if got := config.Load(); got != (snapshotConfig{}) {
+			t.Errorf("got %v, want the zero value", got)
+		}
+
+		config.Update(func(c snapshotConfig) snapshotConfig {
+			c.Version++
+			c.Checksum = c.Version * 10
+			return c
+		})
+		if got, want := config.Load(), (snapshotConfig{Version: 1, Checksum: 10}); got != want {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("readers always see a consistent snapshot", func(t *testing.T) {
+		config := NewSnapshotted(snapshotConfig{Version: 0, Checksum: 0})
+		const readers = 10
+		const updates = 100
+
+		var wg sync.WaitGroup
+		wg.Add(readers + 1)
+		for range readers {
+			go func() {
+				defer wg.Done()
+				for range 1000 {
+					got := config.Load()
+					if got.Checksum != got.Version*10 {
+						t.Errorf("inconsistent snapshot %v", got)
+						return
+					}
+				}
+			}()
+		}
+		go func() {
+			defer wg.Done()
+			for range updates {
+				config.Update(func(c snapshotConfig) snapshotConfig {
+					c.Version++
+					c.Checksum = c.Version * 10
+					return c
+				})
+			}
+		}()
+		wg.Wait()
+
+		if got := config.Load().Version; got != updates {
+			t.Errorf("got version %d, want %d", got, updates)
+		}
+	})
+}
@@ -0,0 +1,34 @@
+package v8
+
+import (
+	"sync"
+	"time"
+)
+
+// Throttle returns a function that calls fn at most once per d. The first
+// call goes through straight away and further calls within d of it are
+// ignored (leading edge). It is safe to call from several goroutines.
+func Throttle(d time.Duration, fn func()) func() {
+	return ThrottleWithClock(RealClock{}, d, fn)
+}
+
+// ThrottleWithClock is Throttle with the window measured by clock.
+func ThrottleWithClock(clock Clock, d time.Duration, fn func()) func() {
+	var (
+		mu   sync.Mutex
+		last time.Time
+	)
+
+	return func() {
+		mu.Lock()
+		now := clock.Now()
+		if !last.IsZero() && now.Sub(last) < d {
+			mu.Unlock()
+			return
+		}
+		last = now
+		mu.Unlock()
+
+		fn()
+	}
+}
@@ -0,0 +1,29 @@
+package v8
+
+import (
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+func TestThrottle(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		calls := 0
+		throttled := Throttle(time.Second, func() { calls++ })
+
+		for range 5 {
+			throttled()
+			time.Sleep(100 * time.Millisecond)
+		}
+		if calls != 1 {
+			t.Errorf("got %d calls within the window, want 1", calls)
+		}
+
+		time.Sleep(time.Second)
+		throttled()
+		throttled()
+		if calls != 2 {
+			t.Errorf("got %d calls after the window, want 2", calls)
+		}
+	})
+}
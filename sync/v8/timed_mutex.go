@@ -0,0 +1,51 @@
+package v8
+
+import "context"
+
+// TimedMutex is a mutual exclusion lock that can also be acquired without
+// waiting (TryLock) or with a deadline (LockContext). Holding the lock means
+// holding the single slot of a buffered channel.
+type TimedMutex struct {
+	slot chan struct{}
+}
+
+// NewTimedMutex returns an unlocked TimedMutex.
+func NewTimedMutex() *TimedMutex {
+	return &TimedMutex{slot: make(chan struct{}, 1)}
+}
+
+// Lock blocks until the lock is acquired.
+func (m *TimedMutex) Lock() {
+	m.slot <- struct{}{}
+}
+
+// TryLock acquires the lock if it is free and reports whether it did.
+func (m *TimedMutex) TryLock() bool {
+	select {
+	case m.slot <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// LockContext blocks until the lock is acquired or ctx is done, in which
+// case it returns ctx.Err() without holding the lock.
+func (m *TimedMutex) LockContext(ctx context.Context) error {
+	select {
+	case m.slot <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Unlock releases the lock. Like sync.Mutex, it panics if the lock isn't
+// held.
+func (m *TimedMutex) Unlock() {
+	select {
+	case <-m.slot:
+	default:
+		panic("v8: unlock of unlocked TimedMutex")
+	}
+}
@@ -0,0 +1,76 @@
+package v8
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+func TestTimedMutex(t *testing.T) {
+	t.Run("TryLock fails while the lock is held", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			mu := NewTimedMutex()
+			mu.Lock()
+
+			got := make(chan bool)
+			go func() { got <- mu.TryLock() }()
+			if <-got {
+				t.Error("TryLock should fail while the lock is held")
+			}
+
+			mu.Unlock()
+			if !mu.TryLock() {
+				t.Error("TryLock should succeed once the lock is released")
+			}
+		})
+	})
+
+	t.Run("LockContext waits for the lock", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			mu := NewTimedMutex()
+			mu.Lock()
+			go func() {
+				time.Sleep(time.Second)
+				mu.Unlock()
+			}()
+
+			start := time.Now()
+			if err := mu.LockContext(context.Background()); err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			if elapsed := time.Since(start); elapsed != time.Second {
+				t.Errorf("locked after %v, want 1s", elapsed)
+			}
+		})
+	})
+
+	t.Run("LockContext gives up when the context is done", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			mu := NewTimedMutex()
+			mu.Lock()
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			err := mu.LockContext(ctx)
+
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("got %v, want %v", err, context.DeadlineExceeded)
+			}
+			mu.Unlock() // still held by us, not by the cancelled caller
+			if !mu.TryLock() {
+				t.Error("the cancelled LockContext should not hold the lock")
+			}
+		})
+	})
+
+	t.Run("Unlock of an unlocked mutex panics", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected a panic")
+			}
+		}()
+		NewTimedMutex().Unlock()
+	})
+}
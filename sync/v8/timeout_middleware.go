@@ -0,0 +1,16 @@
+package v8
+
+import (
+	"net/http"
+	"time"
+)
+
+// TimeoutMiddleware gives each request a context that is cancelled after d.
+// If the handler hasn't finished by then the client gets a 503 and anything
+// the handler writes afterwards is discarded. http.TimeoutHandler already
+// does the tricky part, buffering the response so the two can't interleave.
+func TimeoutMiddleware(d time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.TimeoutHandler(next, d, http.StatusText(http.StatusServiceUnavailable))
+	}
+}
@@ -0,0 +1,55 @@
+package v8
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+func TestTimeoutMiddleware(t *testing.T) {
+	t.Run("slow handler gets a 503", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-time.After(2 * time.Second):
+					w.Write([]byte("Finally responded!"))
+				case <-r.Context().Done():
+				}
+			})
+			handler := TimeoutMiddleware(100 * time.Millisecond)(slow)
+
+			res := httptest.NewRecorder()
+			start := time.Now()
+			handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/slow", nil))
+
+			if res.Code != http.StatusServiceUnavailable {
+				t.Errorf("got status %d, want %d", res.Code, http.StatusServiceUnavailable)
+			}
+			if elapsed := time.Since(start); elapsed != 100*time.Millisecond {
+				t.Errorf("responded after %v, want 100ms", elapsed)
+			}
+		})
+	})
+
+	t.Run("fast handler passes through", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			fast := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(10 * time.Millisecond)
+				w.Write([]byte("quick"))
+			})
+			handler := TimeoutMiddleware(100 * time.Millisecond)(fast)
+
+			res := httptest.NewRecorder()
+			handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/fast", nil))
+
+			if res.Code != http.StatusOK {
+				t.Errorf("got status %d, want %d", res.Code, http.StatusOK)
+			}
+			if got := res.Body.String(); got != "quick" {
+				t.Errorf("got body %q, want %q", got, "quick")
+			}
+		})
+	})
+}
@@ -0,0 +1,99 @@
+package v8
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// TTLLRUCache holds at most capacity entries, evicting the least recently
+// used one when full. Entries also expire ttl after they were put; expired
+// entries are removed lazily, when they are next looked up or evicted.
+type TTLLRUCache[K comparable, V any] struct {
+	capacity int
+	ttl      time.Duration
+	clock    Clock
+
+	mu      sync.Mutex
+	order   *list.List // front is the most recently used
+	entries map[K]*list.Element
+}
+
+type ttlEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time
+}
+
+// NewTTLLRU returns an empty cache. It panics if capacity < 1.
+func NewTTLLRU[K comparable, V any](capacity int, ttl time.Duration) *TTLLRUCache[K, V] {
+	return NewTTLLRUWithClock[K, V](RealClock{}, capacity, ttl)
+}
+
+// NewTTLLRUWithClock is NewTTLLRU with expiry measured by clock.
+func NewTTLLRUWithClock[K comparable, V any](clock Clock, capacity int, ttl time.Duration) *TTLLRUCache[K, V] {
+	if capacity < 1 {
+		panic("v8: TTLLRUCache capacity must be positive")
+	}
+	return &TTLLRUCache[K, V]{
+		capacity: capacity,
+		ttl:      ttl,
+		clock:    clock,
+		order:    list.New(),
+		entries:  make(map[K]*list.Element, capacity),
+	}
+}
+
+// Get returns the value for key and marks it as recently used. Expired
+// entries are removed and reported as missing.
+func (c *TTLLRUCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	entry := el.Value.(*ttlEntry[K, V])
+	if !c.clock.Now().Before(entry.expires) {
+		c.remove(el)
+		var zero V
+		return zero, false
+	}
+
+	c.order.MoveToFront(el)
+	return entry.value, true
+}
+
+// Put stores value for key with a fresh ttl, evicting the least recently
+// used entry if the cache is full.
+func (c *TTLLRUCache[K, V]) Put(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires := c.clock.Now().Add(c.ttl)
+	if el, ok := c.entries[key]; ok {
+		entry := el.Value.(*ttlEntry[K, V])
+		entry.value, entry.expires = value, expires
+		c.order.MoveToFront(el)
+		return
+	}
+
+	if c.order.Len() >= c.capacity {
+		c.remove(c.order.Back())
+	}
+	c.entries[key] = c.order.PushFront(&ttlEntry[K, V]{key: key, value: value, expires: expires})
+}
+
+// Len returns the number of entries held, including expired ones not yet removed.
+func (c *TTLLRUCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *TTLLRUCache[K, V]) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.entries, el.Value.(*ttlEntry[K, V]).key)
+}
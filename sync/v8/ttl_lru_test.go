@@ -0,0 +1,64 @@
+package v8
+
+import (
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+func TestTTLLRUCache(t *testing.T) {
+	t.Run("evicts the least recently used entry at capacity", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			cache := NewTTLLRU[string, int](2, time.Hour)
+			cache.Put("ann", 1)
+			cache.Put("bob", 2)
+			cache.Get("ann") // bob is now the least recently used
+			cache.Put("chris", 3)
+
+			if _, ok := cache.Get("bob"); ok {
+				t.Error("bob should have been evicted")
+			}
+			if got, ok := cache.Get("ann"); !ok || got != 1 {
+				t.Errorf("got %d, %v, want 1, true", got, ok)
+			}
+			if got, ok := cache.Get("chris"); !ok || got != 3 {
+				t.Errorf("got %d, %v, want 3, true", got, ok)
+			}
+		})
+	})
+
+	t.Run("expires an entry after its ttl", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			cache := NewTTLLRU[string, int](2, time.Minute)
+			cache.Put("ann", 1)
+
+			time.Sleep(59 * time.Second)
+			if _, ok := cache.Get("ann"); !ok {
+				t.Error("ann should still be cached just before the ttl")
+			}
+
+			time.Sleep(time.Second)
+			if _, ok := cache.Get("ann"); ok {
+				t.Error("ann should have expired")
+			}
+			if got := cache.Len(); got != 0 {
+				t.Errorf("got %d entries, want the expired one removed", got)
+			}
+		})
+	})
+
+	t.Run("Put refreshes the ttl", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			cache := NewTTLLRU[string, int](2, time.Minute)
+			cache.Put("ann", 1)
+
+			time.Sleep(30 * time.Second)
+			cache.Put("ann", 2)
+			time.Sleep(45 * time.Second)
+
+			if got, ok := cache.Get("ann"); !ok || got != 2 {
+				t.Errorf("got %d, %v, want 2, true", got, ok)
+			}
+		})
+	})
+}
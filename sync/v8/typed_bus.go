@@ -0,0 +1,81 @@
+package v8
+
+import (
+	"reflect"
+	"sync"
+)
+
+// subscriberBuffer is how many events a subscriber can fall behind by
+// before Publish has to wait for it.
+const subscriberBuffer = 16
+
+// TypedBus routes events to subscribers by the event's Go type.
+// Methods can't have type parameters, so the API is the generic functions
+// Subscribe and Publish over a shared registry of channels.
+type TypedBus struct {
+	mu          sync.RWMutex
+	subscribers map[reflect.Type][]any // each element is a chan T for that type
+	closed      bool
+	closeOnce   sync.Once
+	done        chan struct{} // closed by Close, so blocked publishers give up
+}
+
+// NewTypedBus returns a bus with no subscribers.
+func NewTypedBus() *TypedBus {
+	return &TypedBus{
+		subscribers: make(map[reflect.Type][]any),
+		done:        make(chan struct{}),
+	}
+}
+
+// Subscribe returns a channel receiving every event of type T published
+// after this call. The channel is closed when the bus is closed.
+func Subscribe[T any](bus *TypedBus) <-chan T {
+	ch := make(chan T, subscriberBuffer)
+
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+	if bus.closed {
+		close(ch)
+		return ch
+	}
+	key := reflect.TypeFor[T]()
+	bus.subscribers[key] = append(bus.subscribers[key], ch)
+	return ch
+}
+
+// Publish sends event to every subscriber of type T. It waits for a
+// subscriber whose buffer is full, so subscribers must keep reading, but
+// gives up once the bus is closed. Publishing on a closed bus does nothing.
+func Publish[T any](bus *TypedBus, event T) {
+	bus.mu.RLock()
+	defer bus.mu.RUnlock()
+	if bus.closed {
+		return
+	}
+	for _, ch := range bus.subscribers[reflect.TypeFor[T]()] {
+		select {
+		case ch.(chan T) <- event:
+		case <-bus.done:
+			return
+		}
+	}
+}
+
+// Close closes every subscriber channel. Calling it again does nothing.
+func (bus *TypedBus) Close() {
+	bus.closeOnce.Do(func() {
+		// release publishers blocked on a full subscriber first, as they
+		// hold the read lock we need
+		close(bus.done)
+
+		bus.mu.Lock()
+		defer bus.mu.Unlock()
+		bus.closed = true
+		for _, subscribers := range bus.subscribers {
+			for _, ch := range subscribers {
+				reflect.ValueOf(ch).Close()
+			}
+		}
+	})
+}
@@ -0,0 +1,82 @@
+package v8
+
+import (
+	"slices"
+	"testing"
+	"testing/synctest"
+)
+
+type UserCreated struct{ Name string }
+
+type UserDeleted struct{ Name string }
+
+func TestTypedBus(t *testing.T) {
+	t.Run("subscribers only receive their own event type", func(t *testing.T) {
+		bus := NewTypedBus()
+		created := Subscribe[UserCreated](bus)
+		deleted := Subscribe[UserDeleted](bus)
+
+		Publish(bus, UserCreated{Name: "Ann"})
+		Publish(bus, UserDeleted{Name: "Bob"})
+		Publish(bus, UserCreated{Name: "Chris"})
+		bus.Close()
+
+		gotCreated := drain(created)
+		wantCreated := []UserCreated{{Name: "Ann"}, {Name: "Chris"}}
+		if !slices.Equal(gotCreated, wantCreated) {
+			t.Errorf("got %v, want %v", gotCreated, wantCreated)
+		}
+
+		gotDeleted := drain(deleted)
+		wantDeleted := []UserDeleted{{Name: "Bob"}}
+		if !slices.Equal(gotDeleted, wantDeleted) {
+			t.Errorf("got %v, want %v", gotDeleted, wantDeleted)
+		}
+	})
+
+	t.Run("every subscriber of a type gets the event", func(t *testing.T) {
+		bus := NewTypedBus()
+		first := Subscribe[UserCreated](bus)
+		second := Subscribe[UserCreated](bus)
+
+		Publish(bus, UserCreated{Name: "Ann"})
+
+		if got := <-first; got.Name != "Ann" {
+			t.Errorf("first subscriber got %v", got)
+		}
+		if got := <-second; got.Name != "Ann" {
+			t.Errorf("second subscriber got %v", got)
+		}
+	})
+
+	t.Run("publishing with no subscribers or after Close does nothing", func(t *testing.T) {
+		bus := NewTypedBus()
+		Publish(bus, UserCreated{Name: "nobody listening"})
+
+		bus.Close()
+		Publish(bus, UserCreated{Name: "too late"})
+
+		if _, ok := <-Subscribe[UserCreated](bus); ok {
+			t.Error("subscribing to a closed bus should return a closed channel")
+		}
+	})
+
+	t.Run("Close returns while a publisher is blocked on a subscriber that never reads", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			bus := NewTypedBus()
+			Subscribe[UserCreated](bus) // never read
+
+			published := make(chan struct{})
+			go func() {
+				defer close(published)
+				for range subscriberBuffer + 1 {
+					Publish(bus, UserCreated{Name: "gopher"})
+				}
+			}()
+			synctest.Wait() // the last Publish is blocked on the full buffer
+
+			bus.Close()
+			<-published
+		})
+	})
+}
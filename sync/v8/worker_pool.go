@@ -0,0 +1,107 @@
+package v8
+
+import (
+	"context"
+	"sync"
+)
+
+// WorkerPool runs handle on submitted tasks using a fixed number of
+// goroutines. Tasks wait in an unbounded queue until a worker is free.
+type WorkerPool[T any] struct {
+	handle func(context.Context, T)
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	cond    *sync.Cond // signalled when a task is queued or the pool is shutting down
+	queue   []T
+	closed  bool
+	dropped int
+
+	wg sync.WaitGroup
+}
+
+// NewWorkerPool starts workers goroutines that call handle for each task.
+func NewWorkerPool[T any](workers int, handle func(ctx context.Context, task T)) *WorkerPool[T] {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &WorkerPool[T]{
+		handle: handle,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	p.cond = sync.NewCond(&p.mu)
+
+	p.wg.Add(workers)
+	for range workers {
+		go p.work()
+	}
+	return p
+}
+
+// Submit queues task, returning false if the pool is shutting down.
+func (p *WorkerPool[T]) Submit(task T) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return false
+	}
+	p.queue = append(p.queue, task)
+	p.cond.Signal()
+	return true
+}
+
+// Shutdown stops the pool accepting tasks and waits for the in-flight ones
+// to finish. With drainQueued, tasks still waiting in the queue are run
+// first; otherwise they are dropped and counted by Dropped. If ctx is done
+// first, the context passed to the handlers is cancelled and ctx's error is
+// returned.
+func (p *WorkerPool[T]) Shutdown(ctx context.Context, drainQueued bool) error {
+	p.mu.Lock()
+	p.closed = true
+	if !drainQueued {
+		p.dropped += len(p.queue)
+		p.queue = nil
+	}
+	p.cond.Broadcast()
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	defer p.cancel()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Dropped returns how many queued tasks Shutdown discarded.
+func (p *WorkerPool[T]) Dropped() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.dropped
+}
+
+func (p *WorkerPool[T]) work() {
+	defer p.wg.Done()
+	for {
+		p.mu.Lock()
+		for len(p.queue) == 0 && !p.closed {
+			p.cond.Wait()
+		}
+		if len(p.queue) == 0 {
+			p.mu.Unlock()
+			return // closed and nothing left to do
+		}
+		task := p.queue[0]
+		p.queue = p.queue[1:]
+		p.mu.Unlock()
+
+		p.handle(p.ctx, task)
+	}
+}
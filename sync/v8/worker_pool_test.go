@@ -0,0 +1,146 @@
+package v8
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+func TestWorkerPool(t *testing.T) {
+	t.Run("runs at most workers tasks at once", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			var running, maxRunning, done atomic.Int32
+			pool := NewWorkerPool(2, func(ctx context.Context, task int) {
+				now := running.Add(1)
+				for {
+					seen := maxRunning.Load()
+					if now <= seen || maxRunning.CompareAndSwap(seen, now) {
+						break
+					}
+				}
+				time.Sleep(time.Second)
+				running.Add(-1)
+				done.Add(1)
+			})
+
+			start := time.Now()
+			for i := 0; i < 6; i++ {
+				pool.Submit(i)
+			}
+			if err := pool.Shutdown(context.Background(), true); err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			if got := maxRunning.Load(); got != 2 {
+				t.Errorf("got %d tasks running at once, want 2", got)
+			}
+			if got := done.Load(); got != 6 {
+				t.Errorf("got %d tasks done, want 6", got)
+			}
+			if elapsed := time.Since(start); elapsed != 3*time.Second {
+				t.Errorf("took %v, want 3s", elapsed)
+			}
+		})
+	})
+
+	t.Run("Submit returns false after Shutdown", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			pool := NewWorkerPool(1, func(ctx context.Context, task int) {})
+			pool.Shutdown(context.Background(), true)
+
+			if pool.Submit(1) {
+				t.Error("Submit after Shutdown should return false")
+			}
+		})
+	})
+
+	t.Run("Shutdown waits for in-flight tasks", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			var finished atomic.Bool
+			pool := NewWorkerPool(1, func(ctx context.Context, task int) {
+				time.Sleep(time.Second)
+				finished.Store(true)
+			})
+			pool.Submit(1)
+			synctest.Wait() // let the worker pick the task up
+
+			start := time.Now()
+			pool.Shutdown(context.Background(), true)
+
+			if !finished.Load() {
+				t.Error("Shutdown returned before the in-flight task finished")
+			}
+			if elapsed := time.Since(start); elapsed != time.Second {
+				t.Errorf("Shutdown took %v, want 1s", elapsed)
+			}
+		})
+	})
+
+	t.Run("Shutdown gives up when its context is done", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			pool := NewWorkerPool(1, func(ctx context.Context, task int) {
+				<-ctx.Done() // only stops when the pool cancels it
+			})
+			pool.Submit(1)
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			if err := pool.Shutdown(ctx, true); !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+			}
+		})
+	})
+	t.Run("Shutdown drains queued tasks when asked to", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			var done atomic.Int32
+			pool := NewWorkerPool(1, func(ctx context.Context, task int) {
+				time.Sleep(time.Second)
+				done.Add(1)
+			})
+			for i := 0; i < 4; i++ {
+				pool.Submit(i)
+			}
+			synctest.Wait() // one task in flight, three queued
+
+			pool.Shutdown(context.Background(), true)
+
+			if got := done.Load(); got != 4 {
+				t.Errorf("got %d tasks done, want 4", got)
+			}
+			if got := pool.Dropped(); got != 0 {
+				t.Errorf("got %d tasks dropped, want 0", got)
+			}
+		})
+	})
+
+	t.Run("Shutdown drops queued tasks otherwise", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			var done atomic.Int32
+			pool := NewWorkerPool(1, func(ctx context.Context, task int) {
+				time.Sleep(time.Second)
+				done.Add(1)
+			})
+			for i := 0; i < 4; i++ {
+				pool.Submit(i)
+			}
+			synctest.Wait() // one task in flight, three queued
+
+			start := time.Now()
+			pool.Shutdown(context.Background(), false)
+
+			if got := done.Load(); got != 1 {
+				t.Errorf("got %d tasks done, want only the in-flight one", got)
+			}
+			if got := pool.Dropped(); got != 3 {
+				t.Errorf("got %d tasks dropped, want 3", got)
+			}
+			if elapsed := time.Since(start); elapsed != time.Second {
+				t.Errorf("Shutdown took %v, want 1s", elapsed)
+			}
+		})
+	})
+}